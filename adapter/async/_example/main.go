@@ -0,0 +1,35 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/elgopher/yala/adapter/async"
+	"github.com/elgopher/yala/adapter/console"
+	"github.com/elgopher/yala/logger"
+)
+
+// This example shows how to log messages asynchronously, without blocking the logging goroutine
+func main() {
+	ctx := context.Background()
+
+	// create middleware adapter which passes messages to console adapter in a background goroutine:
+	adapter := &async.Adapter{
+		NextAdapter:     console.StdoutAdapter(),
+		QueueSize:       100,
+		Policy:          async.DropOldest, // drop the oldest messages when the queue is full
+		NeverDropErrors: true,
+	}
+	// pass all queued messages before exit:
+	defer adapter.Close()
+
+	log := logger.WithAdapter(adapter)
+
+	for i := 0; i < 1000; i++ {
+		log.With("i", i).Info(ctx, "Message logged asynchronously")
+	}
+
+	adapter.Flush() // wait until all queued messages are passed to console adapter
+
+	fmt.Println("Dropped messages:", adapter.Dropped())
+}
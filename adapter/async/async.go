@@ -0,0 +1,255 @@
+// (c) 2022 Jacek Olszak
+// This code is licensed under MIT license (see LICENSE for details)
+
+// Package async provides middleware adapter passing entries to the next adapter asynchronously.
+package async
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/elgopher/yala/logger"
+)
+
+// DefaultQueueSize is used when Adapter QueueSize is zero.
+const DefaultQueueSize = 1024
+
+// Policy defines what happens when the queue is full.
+type Policy int8
+
+const (
+	// Block blocks the logging goroutine until there is a free space in the queue.
+	Block Policy = iota
+	// DropNewest drops the logged entry.
+	DropNewest
+	// DropOldest drops the oldest entry in the queue to make space for the logged one.
+	DropOldest
+)
+
+// Adapter is a middleware (decorator) adapter which puts entries into a bounded queue. Entries are passed to
+// NextAdapter by a background goroutine, started on first Log.
+//
+// Time and caller program counter (logger.Entry PC) are captured before the entry is put into the queue, if not
+// captured already. Therefore, NextAdapter should use the PC instead of SkippedCallerFrames to report caller.
+// Entries passed by the background goroutine have zero SkippedCallerFrames, because the original stack is gone.
+// Also, stack trace must be captured before, for example by stacktrace.Adapter used as NextAdapter of async.Adapter
+// wrapper. Context passed to NextAdapter is never canceled (see context.WithoutCancel).
+//
+// Please use Flush to wait until queued entries are passed, and Close to stop the background goroutine.
+// Adapter must not be copied after first use.
+type Adapter struct {
+	NextAdapter logger.Adapter
+	// QueueSize is the maximum number of queued entries. Zero value is DefaultQueueSize.
+	QueueSize int
+	// Policy is used when the queue is full. Zero value is Block.
+	Policy Policy
+	// NeverDropErrors changes the policy for entries with logger.ErrorLevel (or more severe) to Block. Also,
+	// such entries are never dropped by DropOldest.
+	NeverDropErrors bool
+
+	startOnce sync.Once
+	mutex     sync.Mutex
+	changed   *sync.Cond // broadcast each time the queue or worker state changes
+	queue     []queuedEntry
+	enqueued  uint64 // number of entries put into the queue
+	done      uint64 // number of entries passed to NextAdapter or dropped from the queue
+	closed    bool
+	stopped   chan struct{}
+	dropped   atomic.Uint64
+}
+
+type queuedEntry struct {
+	ctx   context.Context //nolint:containedctx // entry is logged with this context later
+	entry logger.Entry
+}
+
+// Log puts the entry into the queue. When Adapter is closed, the entry is passed to the next adapter synchronously.
+func (a *Adapter) Log(ctx context.Context, entry logger.Entry) {
+	if a.NextAdapter == nil {
+		return
+	}
+
+	if entry.PC == 0 {
+		entry.PC = callerPC(entry.SkippedCallerFrames)
+	}
+
+	entry.SkippedCallerFrames++ // each middleware adapter must additionally skip one frame
+
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+
+	a.start()
+
+	queued := queuedEntry{ctx: ctx, entry: entry}
+	if ctx != nil {
+		queued.ctx = context.WithoutCancel(ctx)
+	}
+
+	if !a.enqueue(queued) {
+		a.NextAdapter.Log(ctx, entry)
+	}
+}
+
+// enqueue puts the entry into the queue, or drops it. Returns false when Adapter is closed.
+func (a *Adapter) enqueue(e queuedEntry) bool {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	for !a.closed && len(a.queue) >= a.queueSize() {
+		neverDrop := a.NeverDropErrors && !logger.ErrorLevel.MoreSevereThan(e.entry.Level)
+
+		switch {
+		case a.Policy == DropNewest && !neverDrop:
+			a.dropped.Add(1)
+
+			return true
+		case a.Policy == DropOldest && a.dropOldest():
+			continue
+		default:
+			a.changed.Wait()
+		}
+	}
+
+	if a.closed {
+		return false
+	}
+
+	a.queue = append(a.queue, e)
+	a.enqueued++
+	a.changed.Broadcast()
+
+	return true
+}
+
+// dropOldest drops the oldest entry which can be dropped. Returns false if there is no such entry.
+func (a *Adapter) dropOldest() bool {
+	for i, e := range a.queue {
+		if a.NeverDropErrors && !logger.ErrorLevel.MoreSevereThan(e.entry.Level) {
+			continue
+		}
+
+		a.queue = append(a.queue[:i], a.queue[i+1:]...)
+		a.done++
+		a.dropped.Add(1)
+		a.changed.Broadcast()
+
+		return true
+	}
+
+	return false
+}
+
+func (a *Adapter) queueSize() int {
+	if a.QueueSize <= 0 {
+		return DefaultQueueSize
+	}
+
+	return a.QueueSize
+}
+
+func (a *Adapter) start() {
+	a.startOnce.Do(func() {
+		a.mutex.Lock()
+		a.changed = sync.NewCond(&a.mutex)
+		a.stopped = make(chan struct{})
+		a.mutex.Unlock()
+
+		go a.run()
+	})
+}
+
+func (a *Adapter) run() {
+	defer close(a.stopped)
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	for {
+		for len(a.queue) == 0 && !a.closed {
+			a.changed.Wait()
+		}
+
+		if len(a.queue) == 0 {
+			return
+		}
+
+		e := a.queue[0]
+		a.queue[0] = queuedEntry{} // let GC collect the entry
+		a.queue = a.queue[1:]
+		a.changed.Broadcast()
+
+		e.entry.SkippedCallerFrames = 0 // the entry is passed from a different goroutine than it was logged
+
+		a.mutex.Unlock()
+		a.NextAdapter.Log(e.ctx, e.entry)
+		a.mutex.Lock()
+
+		a.done++
+		a.changed.Broadcast()
+	}
+}
+
+// Flush waits until all entries queued before calling Flush are passed to the next adapter (or dropped).
+func (a *Adapter) Flush() {
+	a.start()
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	enqueued := a.enqueued
+	for a.done < enqueued {
+		a.changed.Wait()
+	}
+}
+
+// Close passes all queued entries to the next adapter, stops the background goroutine and then passes the call to
+// the next adapter (see logger.Closer). Entries logged after Close are passed to the next adapter synchronously.
+func (a *Adapter) Close() error {
+	a.start()
+
+	a.mutex.Lock()
+	a.closed = true
+	a.changed.Broadcast()
+	a.mutex.Unlock()
+
+	<-a.stopped
+
+	return logger.Close(a.NextAdapter)
+}
+
+// Dropped returns the number of entries dropped so far.
+func (a *Adapter) Dropped() uint64 {
+	return a.dropped.Load()
+}
+
+// Enabled passes the check to the next adapter. See logger.LevelEnabler.
+func (a *Adapter) Enabled(ctx context.Context, level logger.Level) bool {
+	return a.NextAdapter != nil && logger.Enabled(ctx, a.NextAdapter, level)
+}
+
+// ReportsCaller passes the check to the next adapter. See logger.CallerReporter.
+func (a *Adapter) ReportsCaller() bool {
+	return logger.ReportsCaller(a.NextAdapter)
+}
+
+// Sync waits until all queued entries are passed (see Flush) and then passes the call to the next adapter.
+// See logger.Syncer.
+func (a *Adapter) Sync() error {
+	a.Flush()
+
+	return logger.Sync(a.NextAdapter)
+}
+
+func callerPC(skippedCallerFrames int) uintptr {
+	var pcs [1]uintptr
+
+	const skip = 3 // runtime.Callers, callerPC and Adapter.Log
+
+	runtime.Callers(skippedCallerFrames+skip, pcs[:])
+
+	return pcs[0]
+}
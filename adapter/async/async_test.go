@@ -0,0 +1,389 @@
+// (c) 2022 Jacek Olszak
+// This code is licensed under MIT license (see LICENSE for details)
+
+package async_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/elgopher/yala/adapter/async"
+	"github.com/elgopher/yala/adapter/internal/adaptertest"
+	"github.com/elgopher/yala/adapter/internal/fake"
+	"github.com/elgopher/yala/adapter/logadapter"
+	"github.com/elgopher/yala/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const message = "message"
+
+var ctx = context.Background()
+
+func TestAdapter_Log(t *testing.T) {
+	t.Run("should not panic when next adapter is nil", func(t *testing.T) {
+		adapter := &async.Adapter{}
+		assert.NotPanics(t, func() {
+			adapter.Log(ctx, logger.Entry{Level: logger.InfoLevel, Message: message})
+		})
+	})
+
+	t.Run("should pass entries to next adapter", func(t *testing.T) {
+		next := &fake.Adapter{}
+		adapter := &async.Adapter{NextAdapter: next}
+		defer adapter.Close()
+		// when
+		adapter.Log(ctx, entry(logger.InfoLevel, "1"))
+		adapter.Log(ctx, entry(logger.InfoLevel, "2"))
+		adapter.Flush()
+		// then
+		assert.Equal(t, []string{"1", "2"}, messages(next.Entries()))
+	})
+
+	t.Run("should capture time and caller before putting entry into the queue", func(t *testing.T) {
+		next := &fake.Adapter{}
+		adapter := &async.Adapter{NextAdapter: next}
+		defer adapter.Close()
+		// when
+		_, _, line, _ := runtime.Caller(0)
+		adapter.Log(ctx, entry(logger.InfoLevel, message))
+		adapter.Flush()
+		// then
+		entries := next.Entries()
+		require.Len(t, entries, 1)
+		assert.False(t, entries[0].Time.IsZero())
+		frame, ok := entries[0].Caller()
+		require.True(t, ok)
+		assert.Equal(t, line+1, frame.Line)
+	})
+
+	t.Run("should not override time and caller captured before", func(t *testing.T) {
+		next := &fake.Adapter{}
+		adapter := &async.Adapter{NextAdapter: next}
+		defer adapter.Close()
+		entryTime := time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC)
+		// when
+		adapter.Log(ctx, logger.Entry{Level: logger.InfoLevel, Message: message, Time: entryTime, PC: 1})
+		adapter.Flush()
+		// then
+		entries := next.Entries()
+		require.Len(t, entries, 1)
+		assert.Equal(t, entryTime, entries[0].Time)
+		assert.Equal(t, uintptr(1), entries[0].PC)
+	})
+
+	t.Run("should capture caller using logger", func(t *testing.T) {
+		next := &fake.Adapter{}
+		adapter := &async.Adapter{NextAdapter: next}
+		defer adapter.Close()
+		log := logger.WithAdapter(adapter)
+		// when
+		_, _, line, _ := runtime.Caller(0)
+		log.Info(ctx, message)
+		adapter.Flush()
+		// then
+		entries := next.Entries()
+		require.Len(t, entries, 1)
+		frame, _ := entries[0].Caller()
+		assert.Equal(t, line+1, frame.Line)
+	})
+
+	t.Run("should pass context which is not canceled", func(t *testing.T) {
+		next := &contextAdapterMock{}
+		adapter := &async.Adapter{NextAdapter: next}
+		defer adapter.Close()
+		canceledCtx, cancel := context.WithCancel(context.WithValue(ctx, contextKey{}, "value"))
+		cancel()
+		// when
+		adapter.Log(canceledCtx, entry(logger.InfoLevel, message))
+		adapter.Flush()
+		// then
+		require.Len(t, next.contexts, 1)
+		assert.NoError(t, next.contexts[0].Err())
+		assert.Equal(t, "value", next.contexts[0].Value(contextKey{}))
+	})
+
+	t.Run("should not skip caller frames of the background goroutine", func(t *testing.T) {
+		next := &fake.Adapter{}
+		adapter := &async.Adapter{NextAdapter: next}
+		defer adapter.Close()
+		// when
+		adapter.Log(ctx, logger.Entry{Level: logger.InfoLevel, Message: message, SkippedCallerFrames: 1})
+		adapter.Flush()
+		// then
+		entries := next.Entries()
+		require.Len(t, entries, 1)
+		assert.Zero(t, entries[0].SkippedCallerFrames)
+	})
+
+	t.Run("should skip one more caller frame after Close", func(t *testing.T) {
+		next := &fake.Adapter{}
+		adapter := &async.Adapter{NextAdapter: next}
+		require.NoError(t, adapter.Close())
+		// when
+		adapter.Log(ctx, logger.Entry{Level: logger.InfoLevel, Message: message, SkippedCallerFrames: 1})
+		// then
+		entries := next.Entries()
+		require.Len(t, entries, 1)
+		assert.Equal(t, 2, entries[0].SkippedCallerFrames)
+	})
+
+	t.Run("should print caller using standard log", func(t *testing.T) {
+		var builder strings.Builder
+		adapter := &async.Adapter{NextAdapter: logadapter.Adapter(log.New(&builder, "", log.Lshortfile))}
+		defer adapter.Close()
+		yalaLogger := logger.WithAdapter(adapter)
+		// when
+		yalaLogger.Warn(ctx, message)
+		_, _, line, _ := runtime.Caller(0)
+		adapter.Flush()
+		// then
+		assert.Equal(t, fmt.Sprintf("async_test.go:%d: WARN message\n", line-1), builder.String())
+	})
+
+	t.Run("should not panic when context is nil", func(t *testing.T) {
+		next := &contextAdapterMock{}
+		adapter := &async.Adapter{NextAdapter: next}
+		defer adapter.Close()
+		// when
+		adapter.Log(nil, entry(logger.InfoLevel, message)) //nolint:staticcheck // nil context is tested on purpose
+		adapter.Flush()
+		// then
+		require.Len(t, next.contexts, 1)
+		assert.Nil(t, next.contexts[0])
+	})
+
+	t.Run("should pass entry synchronously after Close", func(t *testing.T) {
+		next := &fake.Adapter{}
+		adapter := &async.Adapter{NextAdapter: next}
+		require.NoError(t, adapter.Close())
+		// when
+		adapter.Log(ctx, entry(logger.InfoLevel, message))
+		// then
+		assert.Len(t, next.Entries(), 1)
+	})
+}
+
+func TestAdapter_Policy(t *testing.T) {
+	tests := map[string]struct {
+		policy           async.Policy
+		neverDropErrors  bool
+		entries          []logger.Entry
+		expectedMessages []string
+		expectedDropped  uint64
+	}{
+		"DropNewest": {
+			policy:           async.DropNewest,
+			entries:          []logger.Entry{entry(logger.InfoLevel, "1"), entry(logger.InfoLevel, "2")},
+			expectedMessages: []string{"blocking", "1"},
+			expectedDropped:  1,
+		},
+		"DropOldest": {
+			policy:           async.DropOldest,
+			entries:          []logger.Entry{entry(logger.InfoLevel, "1"), entry(logger.InfoLevel, "2")},
+			expectedMessages: []string{"blocking", "2"},
+			expectedDropped:  1,
+		},
+		"DropOldest never dropping errors": {
+			policy:          async.DropOldest,
+			neverDropErrors: true,
+			entries: []logger.Entry{
+				entry(logger.ErrorLevel, "1"), entry(logger.InfoLevel, "2"), entry(logger.InfoLevel, "3"),
+			},
+			expectedMessages: []string{"blocking", "1", "3"},
+			expectedDropped:  1,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			next := &blockingAdapterMock{unblock: make(chan struct{})}
+			adapter := &async.Adapter{
+				NextAdapter:     next,
+				QueueSize:       len(test.entries) - 1,
+				Policy:          test.policy,
+				NeverDropErrors: test.neverDropErrors,
+			}
+			defer adapter.Close()
+			adapter.Log(ctx, entry(logger.InfoLevel, "blocking"))
+			next.WaitUntilBlocked()
+			// when
+			for _, e := range test.entries {
+				adapter.Log(ctx, e)
+			}
+			// then
+			close(next.unblock)
+			adapter.Flush()
+			assert.Equal(t, test.expectedMessages, next.Messages())
+			assert.Equal(t, test.expectedDropped, adapter.Dropped())
+		})
+	}
+
+	t.Run("should block when queue is full", func(t *testing.T) {
+		policies := map[string]struct {
+			policy          async.Policy
+			neverDropErrors bool
+			level           logger.Level
+		}{
+			"Block": {policy: async.Block, level: logger.InfoLevel},
+			"DropNewest never dropping errors": {
+				policy: async.DropNewest, neverDropErrors: true, level: logger.ErrorLevel,
+			},
+		}
+
+		for name, test := range policies {
+			t.Run(name, func(t *testing.T) {
+				next := &blockingAdapterMock{unblock: make(chan struct{})}
+				adapter := &async.Adapter{
+					NextAdapter:     next,
+					QueueSize:       1,
+					Policy:          test.policy,
+					NeverDropErrors: test.neverDropErrors,
+				}
+				defer adapter.Close()
+				adapter.Log(ctx, entry(logger.InfoLevel, "blocking"))
+				next.WaitUntilBlocked()
+				adapter.Log(ctx, entry(test.level, "1"))
+
+				logged := make(chan struct{})
+				// when
+				go func() {
+					adapter.Log(ctx, entry(test.level, "2"))
+					close(logged)
+				}()
+				// then
+				select {
+				case <-logged:
+					require.Fail(t, "Log should block")
+				case <-time.After(50 * time.Millisecond):
+				}
+				// and
+				close(next.unblock)
+				<-logged
+				adapter.Flush()
+				assert.Equal(t, []string{"blocking", "1", "2"}, next.Messages())
+				assert.Zero(t, adapter.Dropped())
+			})
+		}
+	})
+}
+
+func TestAdapter_Sync(t *testing.T) {
+	t.Run("should pass queued entries", func(t *testing.T) {
+		next := &fake.Adapter{}
+		adapter := &async.Adapter{NextAdapter: next}
+		defer adapter.Close()
+		adapter.Log(ctx, entry(logger.InfoLevel, message))
+		// when
+		_ = adapter.Sync()
+		// then
+		assert.Len(t, next.Entries(), 1)
+	})
+}
+
+func TestAdapter_Close(t *testing.T) {
+	t.Run("should pass all queued entries", func(t *testing.T) {
+		next := &fake.Adapter{}
+		adapter := &async.Adapter{NextAdapter: next}
+		for i := 0; i < 100; i++ {
+			adapter.Log(ctx, entry(logger.InfoLevel, strconv.Itoa(i)))
+		}
+		// when
+		err := adapter.Close()
+		// then
+		require.NoError(t, err)
+		assert.Len(t, next.Entries(), 100)
+	})
+
+	t.Run("should close unused adapter twice", func(t *testing.T) {
+		adapter := &async.Adapter{NextAdapter: &fake.Adapter{}}
+		assert.NoError(t, adapter.Close())
+		assert.NoError(t, adapter.Close())
+	})
+
+	t.Run("should pass entries logged concurrently", func(t *testing.T) {
+		next := &fake.Adapter{}
+		adapter := &async.Adapter{NextAdapter: next, QueueSize: 2}
+
+		var wg sync.WaitGroup
+
+		const goroutines = 10
+
+		wg.Add(goroutines)
+
+		for i := 0; i < goroutines; i++ {
+			go func() {
+				defer wg.Done()
+
+				for j := 0; j < 10; j++ {
+					adapter.Log(ctx, entry(logger.InfoLevel, message))
+				}
+			}()
+		}
+
+		wg.Wait()
+		// when
+		require.NoError(t, adapter.Close())
+		// then
+		assert.Len(t, next.Entries(), 100)
+	})
+}
+
+func TestAdapter_Middleware(t *testing.T) {
+	adaptertest.RunMiddleware(t, adaptertest.Middleware{
+		NewAdapter: func(next logger.Adapter) logger.Adapter {
+			return &async.Adapter{NextAdapter: next}
+		},
+	})
+}
+
+func entry(level logger.Level, msg string) logger.Entry {
+	return logger.Entry{Level: level, Message: msg}
+}
+
+func messages(entries []logger.Entry) []string {
+	var m []string
+	for _, e := range entries {
+		m = append(m, e.Message)
+	}
+
+	return m
+}
+
+// blockingAdapterMock blocks each Log until unblock channel is closed.
+type blockingAdapterMock struct {
+	fake.Adapter
+	unblock chan struct{}
+}
+
+func (a *blockingAdapterMock) Log(ctx context.Context, entry logger.Entry) {
+	a.Adapter.Log(ctx, entry)
+	<-a.unblock
+}
+
+func (a *blockingAdapterMock) WaitUntilBlocked() {
+	for len(a.Entries()) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func (a *blockingAdapterMock) Messages() []string {
+	return messages(a.Entries())
+}
+
+type contextKey struct{}
+
+type contextAdapterMock struct {
+	contexts []context.Context
+}
+
+func (a *contextAdapterMock) Log(ctx context.Context, _ logger.Entry) {
+	a.contexts = append(a.contexts, ctx)
+}
@@ -24,6 +24,8 @@ func main() {
 		"other_name": "field_value",
 	})
 
+	log.InfoKV(ctx, "Some info with fields in given order", "first", 1, "second", 2)
+
 	log.WarnFields(ctx, "Deprecated configuration parameter. It will be removed.", logger.Fields{
 		"parameter": "some value",
 	})
@@ -7,7 +7,10 @@
 //
 // The format of message produced by console adapters is:
 //
-//	LEVEL message key=value key=value error=error
+//	LEVEL message key=value key=value error=error error.type=type
+//
+// The time is not printed. To print the time of the entry, please use WriterPrinter with printer.Adapter
+// TimeFormat instead.
 package console
 
 import (
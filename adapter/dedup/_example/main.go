@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/elgopher/yala/adapter/console"
+	"github.com/elgopher/yala/adapter/dedup"
+	"github.com/elgopher/yala/logger"
+)
+
+var ErrTimeout = errors.New("timeout")
+
+// This example shows how to collapse repeated messages, for example logged in retry loops
+func main() {
+	ctx := context.Background()
+
+	// create middleware adapter which collapses identical messages logged within 5 seconds:
+	adapter := &dedup.Adapter{
+		NextAdapter: console.StdoutAdapter(),
+		Window:      5 * time.Second,
+	}
+	// pass pending repeated messages before exit:
+	defer adapter.Flush()
+
+	log := logger.WithAdapter(adapter)
+
+	for i := 0; i < 10; i++ {
+		log.WithError(ErrTimeout).Warn(ctx, "Retrying request") // logged once
+	}
+	// "WARN Retrying request repeated=9 error=timeout error.type=*errors.errorString" is logged on Flush
+}
@@ -0,0 +1,273 @@
+// (c) 2022 Jacek Olszak
+// This code is licensed under MIT license (see LICENSE for details)
+
+// Package dedup provides middleware adapter collapsing repeated entries.
+package dedup
+
+import (
+	"container/list"
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/elgopher/yala/adapter/logfmt"
+	"github.com/elgopher/yala/logger"
+)
+
+const (
+	// RepeatedKey is a key of the field containing the number of repeated entries.
+	RepeatedKey = "repeated"
+	// DefaultWindow is used when Adapter Window is zero.
+	DefaultWindow = time.Second
+	// DefaultMaxEntries is used when Adapter MaxEntries is zero.
+	DefaultMaxEntries = 1000
+)
+
+// Adapter is a middleware (decorator) adapter which collapses identical entries (with the same level, logger name,
+// message, fields and error message) logged within a time Window. The first entry is passed to NextAdapter
+// immediately. Repeated entries are counted, and when the window closes, the last repeated entry is passed with
+// an additional field, for example:
+//
+//	WARN retrying request repeated=42 error=timeout
+//
+// Windows are closed by a timer. Please use Flush to pass all pending entries, for example before application exits.
+//
+// Adapter must not be copied after first use.
+type Adapter struct {
+	NextAdapter logger.Adapter
+	// Window is the time in which identical entries are collapsed. Zero value is DefaultWindow.
+	Window time.Duration
+	// MaxEntries is the maximum number of distinct entries remembered at once. When exceeded, the oldest window is
+	// closed earlier. Zero value is DefaultMaxEntries.
+	MaxEntries int
+	// Now returns the current time. When nil, time.Now is used.
+	Now func() time.Time
+	// AfterFunc runs f in its own goroutine after duration d. It returns a function stopping the timer. When nil,
+	// time.AfterFunc is used.
+	AfterFunc func(d time.Duration, f func()) (stop func() bool)
+
+	mutex     sync.Mutex
+	windows   map[string]*list.Element
+	order     *list.List  // windows ordered by the time of closing
+	stopTimer func() bool // not nil, when the timer closing the oldest window is scheduled
+}
+
+type window struct {
+	key       string
+	closesAt  time.Time
+	lastEntry logger.Entry
+	repeated  uint64
+	lastCtx   context.Context //nolint:containedctx // context of the last repeated entry is passed to NextAdapter
+}
+
+// Log passes the entry to the next adapter, unless it is a repeated one.
+func (a *Adapter) Log(ctx context.Context, entry logger.Entry) {
+	if a.NextAdapter == nil {
+		return
+	}
+
+	entry.SkippedCallerFrames++ // each middleware adapter must additionally skip one frame
+
+	closed, repeated := a.register(ctx, entry)
+
+	a.logClosed(closed, entry.SkippedCallerFrames)
+
+	if !repeated {
+		a.NextAdapter.Log(ctx, entry)
+	}
+}
+
+// register registers the entry and returns windows which were closed.
+func (a *Adapter) register(ctx context.Context, entry logger.Entry) (closed []*window, repeated bool) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.windows == nil {
+		a.windows = map[string]*list.Element{}
+		a.order = list.New()
+	}
+
+	now := a.now()
+	closed = a.removeWindows(func(w *window) bool {
+		return !now.Before(w.closesAt)
+	})
+
+	key := entryKey(entry)
+
+	if element, ok := a.windows[key]; ok {
+		w := element.Value.(*window) //nolint:forcetypeassert // only windows are stored
+		w.repeated++
+		w.lastEntry = entry
+		w.lastCtx = ctx
+
+		return closed, true
+	}
+
+	maxEntries := a.MaxEntries
+	if maxEntries <= 0 {
+		maxEntries = DefaultMaxEntries
+	}
+
+	if a.order.Len() >= maxEntries {
+		closed = append(closed, a.removeWindow(a.order.Front()))
+	}
+
+	windowDuration := a.Window
+	if windowDuration <= 0 {
+		windowDuration = DefaultWindow
+	}
+
+	a.windows[key] = a.order.PushBack(&window{key: key, closesAt: now.Add(windowDuration)})
+	a.scheduleClose(now)
+
+	return closed, false
+}
+
+// scheduleClose schedules the timer closing the oldest window, unless it is already scheduled.
+func (a *Adapter) scheduleClose(now time.Time) {
+	if a.stopTimer != nil || a.order.Len() == 0 {
+		return
+	}
+
+	oldest := a.order.Front().Value.(*window) //nolint:forcetypeassert // only windows are stored
+	a.stopTimer = a.afterFunc(oldest.closesAt.Sub(now), a.closeTimerFired)
+}
+
+// closeTimerFired closes windows which time has passed and passes their repeated entries.
+func (a *Adapter) closeTimerFired() {
+	a.mutex.Lock()
+
+	a.stopTimer = nil
+	now := a.now()
+	closed := a.removeWindows(func(w *window) bool {
+		return !now.Before(w.closesAt)
+	})
+	a.scheduleClose(now)
+
+	a.mutex.Unlock()
+
+	a.logClosed(closed, 1) // closeTimerFired
+}
+
+// removeWindows removes the oldest windows, as long as they match the predicate.
+func (a *Adapter) removeWindows(predicate func(*window) bool) []*window {
+	var removed []*window
+
+	for element := a.order.Front(); element != nil; element = a.order.Front() {
+		w := element.Value.(*window) //nolint:forcetypeassert // only windows are stored
+		if !predicate(w) {
+			break
+		}
+
+		removed = append(removed, a.removeWindow(element))
+	}
+
+	return removed
+}
+
+func (a *Adapter) removeWindow(element *list.Element) *window {
+	w := a.order.Remove(element).(*window) //nolint:forcetypeassert // only windows are stored
+	delete(a.windows, w.key)
+
+	return w
+}
+
+// logClosed passes repeated entries of closed windows. skippedCallerFrames is the number of frames skipped
+// by the caller of logClosed.
+func (a *Adapter) logClosed(closed []*window, skippedCallerFrames int) {
+	for _, w := range closed {
+		if w.repeated == 0 {
+			continue
+		}
+
+		entry := w.lastEntry
+		entry.Fields = append(entry.Fields[:len(entry.Fields):len(entry.Fields)], logger.Uint64(RepeatedKey, w.repeated))
+		// the entry is passed from a different place than it was logged
+		entry.SkippedCallerFrames = skippedCallerFrames + 1 // logClosed
+
+		a.NextAdapter.Log(w.lastCtx, entry)
+	}
+}
+
+// Flush closes all windows and passes pending repeated entries to the next adapter.
+func (a *Adapter) Flush() {
+	if a.NextAdapter == nil {
+		return
+	}
+
+	a.mutex.Lock()
+
+	var closed []*window
+	if a.order != nil {
+		closed = a.removeWindows(func(*window) bool { return true })
+	}
+
+	if a.stopTimer != nil {
+		a.stopTimer()
+		a.stopTimer = nil
+	}
+
+	a.mutex.Unlock()
+
+	a.logClosed(closed, 1) // Flush
+}
+
+// Enabled passes the check to the next adapter. See logger.LevelEnabler.
+func (a *Adapter) Enabled(ctx context.Context, level logger.Level) bool {
+	return a.NextAdapter != nil && logger.Enabled(ctx, a.NextAdapter, level)
+}
+
+// ReportsCaller passes the check to the next adapter. See logger.CallerReporter.
+func (a *Adapter) ReportsCaller() bool {
+	return logger.ReportsCaller(a.NextAdapter)
+}
+
+// Sync passes pending repeated entries (see Flush) and then passes the call to the next adapter. See logger.Syncer.
+func (a *Adapter) Sync() error {
+	a.Flush()
+
+	return logger.Sync(a.NextAdapter)
+}
+
+// Close passes pending repeated entries (see Flush) and then passes the call to the next adapter. See logger.Closer.
+func (a *Adapter) Close() error {
+	a.Flush()
+
+	return logger.Close(a.NextAdapter)
+}
+
+func (a *Adapter) now() time.Time {
+	if a.Now == nil {
+		return time.Now()
+	}
+
+	return a.Now()
+}
+
+func (a *Adapter) afterFunc(d time.Duration, f func()) (stop func() bool) {
+	if a.AfterFunc == nil {
+		return time.AfterFunc(d, f).Stop
+	}
+
+	return a.AfterFunc(d, f)
+}
+
+func entryKey(entry logger.Entry) string {
+	var builder strings.Builder
+
+	builder.WriteString(entry.Level.String())
+	builder.WriteByte(0)
+	builder.WriteString(entry.Name)
+	builder.WriteByte(0)
+	builder.WriteString(entry.Message)
+	builder.WriteByte(0)
+	logfmt.WriteFields(&builder, entry.Fields)
+	builder.WriteByte(0)
+
+	if entry.Error != nil {
+		builder.WriteString(entry.Error.Error())
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,304 @@
+// (c) 2022 Jacek Olszak
+// This code is licensed under MIT license (see LICENSE for details)
+
+package dedup_test
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/elgopher/yala/adapter/dedup"
+	"github.com/elgopher/yala/adapter/internal/adaptertest"
+	"github.com/elgopher/yala/adapter/internal/fake"
+	"github.com/elgopher/yala/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const message = "message"
+
+var ctx = context.Background()
+
+func TestAdapter_Log(t *testing.T) {
+	t.Run("should not panic when next adapter is nil", func(t *testing.T) {
+		adapter := &dedup.Adapter{}
+		assert.NotPanics(t, func() {
+			adapter.Log(ctx, logger.Entry{Level: logger.InfoLevel, Message: message})
+			adapter.Flush()
+		})
+	})
+
+	t.Run("should pass first entry and suppress repeated ones", func(t *testing.T) {
+		next := &fake.Adapter{}
+		clock := fake.NewClock(time.Time{})
+		adapter := &dedup.Adapter{NextAdapter: next, Window: time.Second, Now: clock.Now, AfterFunc: clock.AfterFunc}
+		e := logger.Entry{Level: logger.WarnLevel, Message: message}
+		// when
+		adapter.Log(ctx, e)
+		adapter.Log(ctx, e)
+		adapter.Log(ctx, e)
+		// then
+		entries := next.Entries()
+		require.Len(t, entries, 1)
+		assert.Empty(t, entries[0].Fields)
+	})
+
+	t.Run("should pass repeated entry with number of repeats when window closes", func(t *testing.T) {
+		next := &fake.Adapter{}
+		clock := fake.NewClock(time.Time{})
+		adapter := &dedup.Adapter{NextAdapter: next, Window: time.Second, Now: clock.Now, AfterFunc: clock.AfterFunc}
+		e := logger.Entry{
+			Level:   logger.WarnLevel,
+			Message: message,
+			Fields:  []logger.Field{logger.String("k", "v")},
+			Error:   errors.New("err"),
+		}
+		adapter.Log(ctx, e)
+		adapter.Log(ctx, e)
+		adapter.Log(ctx, e)
+		clock.Advance(time.Second)
+		// when
+		adapter.Log(ctx, logger.Entry{Level: logger.InfoLevel, Message: "another"})
+		// then
+		entries := next.Entries()
+		require.Len(t, entries, 3)
+		repeatedEntry := entries[1]
+		assert.Equal(t, logger.WarnLevel, repeatedEntry.Level)
+		assert.Equal(t, message, repeatedEntry.Message)
+		assert.Equal(t, e.Error, repeatedEntry.Error)
+		expectedFields := []logger.Field{logger.String("k", "v"), logger.Uint64(dedup.RepeatedKey, 2)}
+		assert.Equal(t, expectedFields, repeatedEntry.Fields)
+		assert.Equal(t, "another", entries[2].Message)
+	})
+
+	t.Run("should pass repeated entry when window closes without further entries", func(t *testing.T) {
+		next := &fake.Adapter{}
+		clock := fake.NewClock(time.Time{})
+		adapter := &dedup.Adapter{NextAdapter: next, Window: time.Second, Now: clock.Now, AfterFunc: clock.AfterFunc}
+		e := logger.Entry{Level: logger.WarnLevel, Message: message}
+		adapter.Log(ctx, e)
+		adapter.Log(ctx, e)
+		// when
+		clock.Advance(time.Second)
+		// then
+		entries := next.Entries()
+		require.Len(t, entries, 2)
+		assert.Equal(t, []logger.Field{logger.Uint64(dedup.RepeatedKey, 1)}, entries[1].Fields)
+		assert.Zero(t, clock.Timers())
+	})
+
+	t.Run("should schedule closing of the next window", func(t *testing.T) {
+		next := &fake.Adapter{}
+		clock := fake.NewClock(time.Time{})
+		adapter := &dedup.Adapter{NextAdapter: next, Window: time.Second, Now: clock.Now, AfterFunc: clock.AfterFunc}
+		first := logger.Entry{Level: logger.WarnLevel, Message: "1"}
+		second := logger.Entry{Level: logger.WarnLevel, Message: "2"}
+		adapter.Log(ctx, first)
+		clock.Advance(500 * time.Millisecond)
+		adapter.Log(ctx, second)
+		adapter.Log(ctx, second)
+		clock.Advance(500 * time.Millisecond)
+		// when
+		clock.Advance(500 * time.Millisecond)
+		// then
+		entries := next.Entries()
+		require.Len(t, entries, 3)
+		assert.Equal(t, "2", entries[2].Message)
+		assert.Equal(t, []logger.Field{logger.Uint64(dedup.RepeatedKey, 1)}, entries[2].Fields)
+	})
+
+	t.Run("should pass entry again after window closes", func(t *testing.T) {
+		next := &fake.Adapter{}
+		clock := fake.NewClock(time.Time{})
+		adapter := &dedup.Adapter{NextAdapter: next, Window: time.Second, Now: clock.Now, AfterFunc: clock.AfterFunc}
+		e := logger.Entry{Level: logger.WarnLevel, Message: message}
+		adapter.Log(ctx, e)
+		clock.Advance(time.Second)
+		// when
+		adapter.Log(ctx, e)
+		// then
+		entries := next.Entries()
+		require.Len(t, entries, 2)
+		assert.Empty(t, entries[1].Fields)
+	})
+
+	t.Run("should not collapse entries which are different", func(t *testing.T) {
+		tests := map[string]logger.Entry{
+			"level":   {Level: logger.ErrorLevel, Message: message},
+			"message": {Level: logger.WarnLevel, Message: "another"},
+			"fields":  {Level: logger.WarnLevel, Message: message, Fields: []logger.Field{logger.Int("k", 1)}},
+			"error":   {Level: logger.WarnLevel, Message: message, Error: errors.New("err")},
+			"name":    {Level: logger.WarnLevel, Message: message, Name: "db"},
+		}
+
+		for name, differentEntry := range tests {
+			t.Run(name, func(t *testing.T) {
+				next := &fake.Adapter{}
+				clock := fake.NewClock(time.Time{})
+				adapter := &dedup.Adapter{NextAdapter: next, Now: clock.Now, AfterFunc: clock.AfterFunc}
+				// when
+				adapter.Log(ctx, logger.Entry{Level: logger.WarnLevel, Message: message})
+				adapter.Log(ctx, differentEntry)
+				// then
+				assert.Len(t, next.Entries(), 2)
+			})
+		}
+	})
+
+	t.Run("should close the oldest window when MaxEntries is exceeded", func(t *testing.T) {
+		next := &fake.Adapter{}
+		clock := fake.NewClock(time.Time{})
+		adapter := &dedup.Adapter{NextAdapter: next, MaxEntries: 2, Now: clock.Now, AfterFunc: clock.AfterFunc}
+		first := logger.Entry{Level: logger.WarnLevel, Message: "1"}
+		adapter.Log(ctx, first)
+		adapter.Log(ctx, first)
+		adapter.Log(ctx, logger.Entry{Level: logger.WarnLevel, Message: "2"})
+		// when
+		adapter.Log(ctx, logger.Entry{Level: logger.WarnLevel, Message: "3"})
+		// then
+		entries := next.Entries()
+		require.Len(t, entries, 4)
+		assert.Equal(t, "1", entries[2].Message)
+		assert.Equal(t, []logger.Field{logger.Uint64(dedup.RepeatedKey, 1)}, entries[2].Fields)
+		assert.Equal(t, "3", entries[3].Message)
+		// and
+		adapter.Log(ctx, first)
+		assert.Len(t, next.Entries(), 5, "first entry should be passed again")
+	})
+
+	t.Run("should pass context of the last repeated entry", func(t *testing.T) {
+		next := &contextAdapterMock{}
+		clock := fake.NewClock(time.Time{})
+		adapter := &dedup.Adapter{NextAdapter: next, Now: clock.Now, AfterFunc: clock.AfterFunc}
+		e := logger.Entry{Level: logger.WarnLevel, Message: message}
+		adapter.Log(ctx, e)
+		lastCtx := context.WithValue(ctx, contextKey{}, "last")
+		adapter.Log(lastCtx, e)
+		// when
+		adapter.Flush()
+		// then
+		require.Len(t, next.contexts, 2)
+		assert.Equal(t, lastCtx, next.contexts[1])
+	})
+
+	t.Run("should skip one more caller frame", func(t *testing.T) {
+		next := &fake.Adapter{}
+		adapter := &dedup.Adapter{NextAdapter: next}
+		// when
+		adapter.Log(ctx, logger.Entry{Level: logger.InfoLevel, Message: message, SkippedCallerFrames: 1})
+		// then
+		entries := next.Entries()
+		require.Len(t, entries, 1)
+		assert.Equal(t, 2, entries[0].SkippedCallerFrames)
+	})
+
+	t.Run("should report caller of Log for repeated entry passed when window closes", func(t *testing.T) {
+		next := &callerAdapterMock{}
+		clock := fake.NewClock(time.Time{})
+		timers := fake.NewClock(time.Time{}) // timer is late, so the window is closed by Log
+		adapter := &dedup.Adapter{NextAdapter: next, Now: clock.Now, AfterFunc: timers.AfterFunc}
+		e := logger.Entry{Level: logger.WarnLevel, Message: message}
+		adapter.Log(ctx, e)
+		adapter.Log(ctx, e)
+		clock.Advance(time.Hour)
+		// when
+		_, _, line, _ := runtime.Caller(0)
+		adapter.Log(ctx, logger.Entry{Level: logger.InfoLevel, Message: "another"})
+		// then
+		require.Len(t, next.lines, 3)
+		assert.Equal(t, strconv.Itoa(line+1), next.lines[1])
+	})
+}
+
+func TestAdapter_Flush(t *testing.T) {
+	t.Run("should pass all pending repeated entries", func(t *testing.T) {
+		next := &fake.Adapter{}
+		clock := fake.NewClock(time.Time{})
+		adapter := &dedup.Adapter{NextAdapter: next, Now: clock.Now, AfterFunc: clock.AfterFunc}
+		e1 := logger.Entry{Level: logger.WarnLevel, Message: "1"}
+		e2 := logger.Entry{Level: logger.WarnLevel, Message: "2"}
+		adapter.Log(ctx, e1)
+		adapter.Log(ctx, e1)
+		adapter.Log(ctx, e2)
+		// when
+		adapter.Flush()
+		// then
+		entries := next.Entries()
+		require.Len(t, entries, 3)
+		assert.Equal(t, "1", entries[2].Message)
+		assert.Equal(t, []logger.Field{logger.Uint64(dedup.RepeatedKey, 1)}, entries[2].Fields)
+	})
+
+	t.Run("should report caller of Flush", func(t *testing.T) {
+		next := &callerAdapterMock{}
+		adapter := &dedup.Adapter{NextAdapter: next}
+		e := logger.Entry{Level: logger.WarnLevel, Message: message}
+		adapter.Log(ctx, e)
+		adapter.Log(ctx, e)
+		// when
+		_, _, line, _ := runtime.Caller(0)
+		adapter.Flush()
+		// then
+		require.Len(t, next.lines, 2)
+		assert.Equal(t, strconv.Itoa(line+1), next.lines[1])
+	})
+}
+
+func TestAdapter_Sync(t *testing.T) {
+	t.Run("should pass pending repeated entries", func(t *testing.T) {
+		next := &fake.Adapter{}
+		adapter := &dedup.Adapter{NextAdapter: next}
+		e := logger.Entry{Level: logger.WarnLevel, Message: message}
+		adapter.Log(ctx, e)
+		adapter.Log(ctx, e)
+		// when
+		_ = adapter.Sync()
+		// then
+		assert.Len(t, next.Entries(), 2)
+	})
+}
+
+func TestAdapter_Close(t *testing.T) {
+	t.Run("should pass pending repeated entries", func(t *testing.T) {
+		next := &fake.Adapter{}
+		adapter := &dedup.Adapter{NextAdapter: next}
+		e := logger.Entry{Level: logger.WarnLevel, Message: message}
+		adapter.Log(ctx, e)
+		adapter.Log(ctx, e)
+		// when
+		_ = adapter.Close()
+		// then
+		assert.Len(t, next.Entries(), 2)
+	})
+}
+
+func TestAdapter_Middleware(t *testing.T) {
+	adaptertest.RunMiddleware(t, adaptertest.Middleware{
+		NewAdapter: func(next logger.Adapter) logger.Adapter {
+			return &dedup.Adapter{NextAdapter: next}
+		},
+	})
+}
+
+type contextKey struct{}
+
+type contextAdapterMock struct {
+	contexts []context.Context
+}
+
+func (a *contextAdapterMock) Log(ctx context.Context, _ logger.Entry) {
+	a.contexts = append(a.contexts, ctx)
+}
+
+type callerAdapterMock struct {
+	lines []string
+}
+
+func (a *callerAdapterMock) Log(_ context.Context, entry logger.Entry) {
+	_, _, line, _ := runtime.Caller(entry.SkippedCallerFrames + 1)
+	a.lines = append(a.lines, strconv.Itoa(line))
+}
@@ -6,6 +6,9 @@ package glogadapter
 
 import (
 	"context"
+	"path/filepath"
+	"runtime"
+	"strconv"
 	"strings"
 
 	"github.com/elgopher/yala/adapter/logfmt"
@@ -16,20 +19,43 @@ import (
 // Adapter is a logger.Adapter implementation, which is using `glog` package (https://github.com/golang/glog).
 type Adapter struct{}
 
+// CallerKey is a key of the field containing the caller of the entry logged by a different goroutine (for example
+// by async.Adapter) or replayed later (for example by logger.Global buffering early entries). glog always reports
+// the caller found on the current stack, therefore the caller of such entry is logged as a field instead,
+// for example "caller=main.go:16".
+const CallerKey = "caller"
+
 // Log logs the entry using glog package.
 func (a Adapter) Log(_ context.Context, entry logger.Entry) {
 	var fieldsAndError strings.Builder
 
-	if len(entry.Fields) > 0 {
+	if frame, ok := entry.Caller(); ok && !onStack(entry.PC) {
+		caller := filepath.Base(frame.File) + ":" + strconv.Itoa(frame.Line)
+		logfmt.WriteField(&fieldsAndError, logger.String(CallerKey, caller))
+	}
+
+	if entry.Name != "" {
+		if fieldsAndError.Len() > 0 {
+			fieldsAndError.WriteByte(' ')
+		}
+
+		logfmt.WriteField(&fieldsAndError, logger.String(logger.NameKey, entry.Name))
+	}
+
+	if logfmt.HasFieldsWithValue(entry.Fields) {
+		if fieldsAndError.Len() > 0 {
+			fieldsAndError.WriteByte(' ')
+		}
+
 		logfmt.WriteFields(&fieldsAndError, entry.Fields)
 	}
 
 	if entry.Error != nil {
-		if len(entry.Fields) > 0 {
+		if fieldsAndError.Len() > 0 {
 			fieldsAndError.WriteByte(' ')
 		}
 
-		logfmt.WriteField(&fieldsAndError, logger.Field{Key: "error", Value: entry.Error})
+		logfmt.WriteError(&fieldsAndError, "error", entry.Error)
 	}
 
 	fieldsAndErrorString := fieldsAndError.String()
@@ -49,3 +75,24 @@ func (a Adapter) Log(_ context.Context, entry logger.Entry) {
 		glog.InfoDepth(depth, message, " ", fieldsAndErrorString)
 	}
 }
+
+// onStack returns true if pc is a program counter of a function on the current goroutine stack.
+func onStack(pc uintptr) bool {
+	pcs := make([]uintptr, 64)
+
+	for {
+		n := runtime.Callers(2, pcs) // skip runtime.Callers and onStack
+		for _, stackPC := range pcs[:n] {
+			if stackPC == pc {
+				return true
+			}
+		}
+
+		if n < len(pcs) {
+			return false
+		}
+
+		pcs = make([]uintptr, 2*len(pcs))
+	}
+}
+
@@ -2,7 +2,9 @@ package glogadapter_test
 
 import (
 	"context"
+	"fmt"
 	"regexp"
+	"runtime"
 	"strings"
 	"testing"
 
@@ -69,6 +71,93 @@ func TestAdapter_Log(t *testing.T) {
 		assert.Equal(t, "k=v", msg.fields)
 		assert.Equal(t, message, msg.message)
 	})
+
+	t.Run("should log secret as redacted", func(t *testing.T) {
+		stderr := fake.UseFakeStderr(t)
+		defer stderr.Release()
+
+		adapter := glogadapter.Adapter{}
+		// when
+		entry := logger.Entry{
+			Level:   logger.ErrorLevel,
+			Message: message,
+		}.With(logger.Any("password", logger.NewSecret("pass")))
+		adapter.Log(context.Background(), entry)
+		// then
+		msg := unmarshalLine(t, stderr.String(t))
+		assert.Equal(t, "password=[REDACTED]", msg.fields)
+	})
+
+	t.Run("should log message with name and field", func(t *testing.T) {
+		stderr := fake.UseFakeStderr(t)
+		defer stderr.Release()
+
+		adapter := glogadapter.Adapter{}
+		// when
+		entry := logger.Entry{
+			Level:   logger.ErrorLevel,
+			Message: message,
+			Name:    "payments.db",
+		}.With(logger.String("k", "v"))
+		adapter.Log(context.Background(), entry)
+		// then
+		msg := unmarshalLine(t, stderr.String(t))
+		assert.Equal(t, "logger=payments.db k=v", msg.fields)
+	})
+}
+
+func TestAdapter_Log_Caller(t *testing.T) {
+	const message = "message"
+
+	t.Run("should log caller which is not on the current stack as field", func(t *testing.T) {
+		stderr := fake.UseFakeStderr(t)
+		defer stderr.Release()
+
+		var pcs [1]uintptr
+		runtime.Callers(1, pcs[:])
+		_, _, line, _ := runtime.Caller(0)
+
+		adapter := glogadapter.Adapter{}
+		// when
+		adapter.Log(context.Background(), logger.Entry{
+			Level:   logger.ErrorLevel,
+			Message: message,
+			PC:      pcs[0],
+		})
+		// then
+		msg := unmarshalLine(t, stderr.String(t))
+		assert.Equal(t, fmt.Sprintf("caller=glog_test.go:%d", line-1), msg.fields)
+	})
+
+	t.Run("should not log caller which is on the current stack as field", func(t *testing.T) {
+		stderr := fake.UseFakeStderr(t)
+		defer stderr.Release()
+
+		adapter := pcCapturingAdapter{next: glogadapter.Adapter{}}
+		// when
+		adapter.Log(context.Background(), logger.Entry{
+			Level:   logger.ErrorLevel,
+			Message: message,
+		})
+		_, _, line, _ := runtime.Caller(0)
+		// then
+		msg := unmarshalLine(t, stderr.String(t))
+		assert.Equal(t, fmt.Sprintf("glog_test.go:%d", line-4), msg.caller)
+		assert.Empty(t, msg.fields)
+	})
+}
+
+// pcCapturingAdapter captures PC of the caller of Log, like logger.Logger does.
+type pcCapturingAdapter struct {
+	next logger.Adapter
+}
+
+func (a pcCapturingAdapter) Log(ctx context.Context, entry logger.Entry) {
+	var pcs [1]uintptr
+	runtime.Callers(2, pcs[:])
+	entry.PC = pcs[0]
+	entry.SkippedCallerFrames++
+	a.next.Log(ctx, entry)
 }
 
 func unmarshalLine(t *testing.T, line string) glogMessage {
@@ -33,7 +33,9 @@ type Message struct {
 	IntField       int
 	Int64Field     int64
 	Float32Field   float32
+	Uint64Field    uint64
 	Float64Field   float64
+	BoolField      bool
 	TimeField      time.Time
 	InterfaceField InterfaceField
 }
@@ -155,6 +157,86 @@ func Run(t *testing.T, subject Subject) { //nolint
 		}
 	})
 
+	t.Run("should log message with typed field", func(t *testing.T) {
+		fields := map[string]struct {
+			field    logger.Field
+			expected interface{}
+			get      func(Message) interface{}
+		}{
+			"String": {
+				field:    logger.String("StringField", "value"),
+				expected: "value",
+				get: func(message Message) interface{} {
+					return message.StringField
+				},
+			},
+			"Int64": {
+				field:    logger.Int64("Int64Field", -1),
+				expected: int64(-1),
+				get: func(message Message) interface{} {
+					return message.Int64Field
+				},
+			},
+			"Uint64": {
+				field:    logger.Uint64("Uint64Field", 1),
+				expected: uint64(1),
+				get: func(message Message) interface{} {
+					return message.Uint64Field
+				},
+			},
+			"Float64": {
+				field:    logger.Float64("Float64Field", 1.1),
+				expected: 1.1,
+				get: func(message Message) interface{} {
+					return message.Float64Field
+				},
+			},
+			"Bool": {
+				field:    logger.Bool("BoolField", true),
+				expected: true,
+				get: func(message Message) interface{} {
+					return message.BoolField
+				},
+			},
+			"Time": {
+				field:    logger.Time("TimeField", time.Unix(1000, 0).UTC()),
+				expected: time.Unix(1000, 0).UTC(),
+				get: func(message Message) interface{} {
+					return message.TimeField
+				},
+			},
+			"Any": {
+				field:    logger.Any("InterfaceField", InterfaceField{NestedField: "nested"}),
+				expected: InterfaceField{NestedField: "nested"},
+				get: func(message Message) interface{} {
+					return message.InterfaceField
+				},
+			},
+		}
+
+		for name, field := range fields {
+			t.Run(name, func(t *testing.T) {
+				var builder strings.Builder
+				adapter := subject.NewAdapter(&builder)
+				// when
+				adapter.Log(ctx, entry.With(field.field))
+				// then
+				out := subject.UnmarshalMessage(t, builder.String())
+				assert.Equal(t, field.expected, field.get(out))
+			})
+		}
+	})
+
+	t.Run("should log secret as redacted", func(t *testing.T) {
+		var builder strings.Builder
+		adapter := subject.NewAdapter(&builder)
+		// when
+		adapter.Log(ctx, entry.With(logger.Any("StringField", logger.NewSecret("password"))))
+		// then
+		out := subject.UnmarshalMessage(t, builder.String())
+		assert.Equal(t, logger.Redacted, out.StringField)
+	})
+
 	t.Run("should log message with fields and error", func(t *testing.T) {
 		const (
 			stringFieldValue = "string"
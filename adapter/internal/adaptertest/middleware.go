@@ -0,0 +1,97 @@
+// (c) 2022 Jacek Olszak
+// This code is licensed under MIT license (see LICENSE for details)
+
+package adaptertest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/elgopher/yala/adapter/internal/fake"
+	"github.com/elgopher/yala/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+// Middleware is configuration of middleware (decorator) logger.Adapter under test.
+type Middleware struct {
+	// NewAdapter creates a new middleware passing entries to the next adapter. next is nil when middleware without
+	// the next adapter is tested.
+	NewAdapter func(next logger.Adapter) logger.Adapter
+}
+
+// RunMiddleware runs tests common to all middleware adapters, which pass Enabled, ReportsCaller, Sync and Close
+// to the next adapter.
+func RunMiddleware(t *testing.T, middleware Middleware) {
+	t.Helper()
+
+	ctx := context.Background()
+	errNext := errors.New("next adapter error")
+
+	newAdapter := func(t *testing.T, next logger.Adapter) logger.Adapter {
+		t.Helper()
+
+		adapter := middleware.NewAdapter(next)
+		t.Cleanup(func() {
+			_ = logger.Close(adapter) // stops background goroutines, if any
+		})
+
+		return adapter
+	}
+
+	t.Run("Enabled", func(t *testing.T) {
+		t.Run("should return false when next adapter is nil", func(t *testing.T) {
+			adapter := newAdapter(t, nil)
+			assert.False(t, logger.Enabled(ctx, adapter, logger.ErrorLevel))
+		})
+
+		t.Run("should pass the check to the next adapter", func(t *testing.T) {
+			adapter := newAdapter(t, fake.LevelEnablerAdapter{MinLevel: logger.WarnLevel})
+			assert.False(t, logger.Enabled(ctx, adapter, logger.InfoLevel))
+			assert.True(t, logger.Enabled(ctx, adapter, logger.WarnLevel))
+		})
+	})
+
+	t.Run("ReportsCaller", func(t *testing.T) {
+		t.Run("should pass the check to the next adapter", func(t *testing.T) {
+			for _, reported := range []bool{true, false} {
+				adapter := newAdapter(t, &fake.Adapter{CallerReported: reported})
+				assert.Equal(t, reported, logger.ReportsCaller(adapter))
+			}
+		})
+	})
+
+	t.Run("Sync", func(t *testing.T) {
+		t.Run("should return nil when next adapter is nil", func(t *testing.T) {
+			adapter := newAdapter(t, nil)
+			assert.NoError(t, logger.Sync(adapter))
+		})
+
+		t.Run("should pass the call to the next adapter", func(t *testing.T) {
+			next := &fake.Adapter{Err: errNext}
+			adapter := newAdapter(t, next)
+			// when
+			err := logger.Sync(adapter)
+			// then
+			assert.ErrorIs(t, err, errNext)
+			assert.Equal(t, 1, next.SyncCalls())
+		})
+	})
+
+	t.Run("Close", func(t *testing.T) {
+		t.Run("should return nil when next adapter is nil", func(t *testing.T) {
+			adapter := newAdapter(t, nil)
+			assert.NoError(t, logger.Close(adapter))
+		})
+
+		t.Run("should pass the call to the next adapter", func(t *testing.T) {
+			next := &fake.Adapter{Err: errNext}
+			adapter := middleware.NewAdapter(next)
+			// when
+			err := logger.Close(adapter)
+			// then
+			assert.ErrorIs(t, err, errNext)
+			assert.Equal(t, 1, next.CloseCalls())
+		})
+	})
+}
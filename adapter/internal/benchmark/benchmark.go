@@ -48,6 +48,18 @@ func Adapter(b *testing.B, adapter logger.Adapter) { //nolint:funlen
 		}
 	})
 
+	b.Run("global logger info with three key values", func(b *testing.B) {
+		var global logger.Global
+		global.SetAdapter(adapter)
+
+		b.ReportAllocs()
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			global.InfoKV(ctx, "msg", "field1", "value", "field2", "value", "field3", "value")
+		}
+	})
+
 	b.Run("normal logger info", func(b *testing.B) {
 		log := logger.WithAdapter(adapter)
 
@@ -0,0 +1,90 @@
+// (c) 2022 Jacek Olszak
+// This code is licensed under MIT license (see LICENSE for details)
+
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"github.com/elgopher/yala/logger"
+)
+
+// Adapter is a fake logger.Adapter which records all logged entries. It also implements logger.Syncer,
+// logger.Closer and logger.CallerReporter. It is safe for concurrent use.
+type Adapter struct {
+	// Err is returned by Sync and Close.
+	Err error
+	// CallerReported is returned by ReportsCaller.
+	CallerReported bool
+
+	mutex      sync.Mutex
+	entries    []logger.Entry
+	syncCalls  int
+	closeCalls int
+}
+
+func (a *Adapter) Log(_ context.Context, entry logger.Entry) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.entries = append(a.entries, entry)
+}
+
+// Entries returns a copy of all logged entries.
+func (a *Adapter) Entries() []logger.Entry {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	return append([]logger.Entry(nil), a.entries...)
+}
+
+func (a *Adapter) ReportsCaller() bool {
+	return a.CallerReported
+}
+
+func (a *Adapter) Sync() error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.syncCalls++
+
+	return a.Err
+}
+
+func (a *Adapter) Close() error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.closeCalls++
+
+	return a.Err
+}
+
+// SyncCalls returns the number of Sync calls.
+func (a *Adapter) SyncCalls() int {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	return a.syncCalls
+}
+
+// CloseCalls returns the number of Close calls.
+func (a *Adapter) CloseCalls() int {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	return a.closeCalls
+}
+
+// LevelEnablerAdapter is a fake logger.Adapter which implements logger.LevelEnabler. Logged entries are discarded.
+type LevelEnablerAdapter struct {
+	// MinLevel is the least severe level enabled by the adapter.
+	MinLevel logger.Level
+}
+
+func (a LevelEnablerAdapter) Log(context.Context, logger.Entry) {}
+
+func (a LevelEnablerAdapter) Enabled(_ context.Context, level logger.Level) bool {
+	return !a.MinLevel.MoreSevereThan(level)
+}
@@ -0,0 +1,101 @@
+// (c) 2022 Jacek Olszak
+// This code is licensed under MIT license (see LICENSE for details)
+
+package fake
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock is a fake clock. Functions scheduled with AfterFunc are run synchronously by Advance, once their time comes.
+// It is safe for concurrent use.
+type Clock struct {
+	mutex  sync.Mutex
+	now    time.Time
+	timers []*timer
+}
+
+type timer struct {
+	at time.Time
+	f  func()
+}
+
+// NewClock returns a Clock showing given time.
+func NewClock(now time.Time) *Clock {
+	return &Clock{now: now}
+}
+
+// Now returns the current time of the clock.
+func (c *Clock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.now
+}
+
+// AfterFunc schedules f to run after d. It returns a function which stops the timer. Stop returns false if the timer
+// has already been run or stopped.
+func (c *Clock) AfterFunc(d time.Duration, f func()) (stop func() bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	t := &timer{at: c.now.Add(d), f: f}
+	c.timers = append(c.timers, t)
+
+	return func() bool {
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+
+		return c.remove(t)
+	}
+}
+
+// Advance moves the clock forward and runs all functions which time has come, in the order of their time.
+func (c *Clock) Advance(d time.Duration) {
+	c.mutex.Lock()
+	c.now = c.now.Add(d)
+
+	var due []*timer
+
+	for _, t := range c.timers {
+		if !t.at.After(c.now) {
+			due = append(due, t)
+		}
+	}
+
+	for _, t := range due {
+		c.remove(t)
+	}
+
+	c.mutex.Unlock()
+
+	sort.SliceStable(due, func(i, j int) bool {
+		return due[i].at.Before(due[j].at)
+	})
+
+	for _, t := range due {
+		t.f()
+	}
+}
+
+// Timers returns the number of scheduled functions which have not been run or stopped yet.
+func (c *Clock) Timers() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return len(c.timers)
+}
+
+func (c *Clock) remove(t *timer) bool {
+	for i, scheduled := range c.timers {
+		if scheduled == t {
+			c.timers = append(c.timers[:i], c.timers[i+1:]...)
+
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"flag"
+
+	"github.com/elgopher/yala/adapter/console"
+	"github.com/elgopher/yala/adapter/levelfilter"
+	"github.com/elgopher/yala/logger"
+)
+
+// This example shows how to filter messages by level (if the library of your choice does not support that,
+// or you want to use different filtering for different loggers). The level can be set using -log-level flag,
+// and changed at runtime. Levels of named loggers can be set using -log-rules flag, for example
+// -log-rules=payments.db=debug,*.pool=error
+func main() {
+	level := levelfilter.NewAtomicLevel(logger.WarnLevel)
+	flag.Var(level, "log-level", "minimum level of logged messages, for example debug")
+
+	rules := levelfilter.NewRules(levelfilter.Rule{Pattern: "payments", Level: logger.InfoLevel})
+	flag.Var(rules, "log-rules", "minimum levels of named loggers, for example payments.db=debug,*.pool=error")
+	flag.Parse()
+
+	// create middleware adapter which filters messages by level
+	adapter := levelfilter.Adapter{
+		NextAdapter: console.StdoutAdapter(),
+		Level:       level,
+		Rules:       rules,
+	}
+	log := logger.WithAdapter(adapter)
+
+	ctx := context.Background()
+
+	// The chain of execution will look like this:
+	// log.Info() -> levelfilter.Adapter -> console adapter
+	log.Info(ctx, "will be filtered out")
+	log.Warn(ctx, "will be logged")
+
+	// level of named logger is taken from the most specific rule matching the name
+	log.Named("payments").Named("db").Info(ctx, "will be logged, because payments rule matches payments.db")
+
+	level.SetLevel(logger.InfoLevel) // level can be changed at any time
+
+	log.Info(ctx, "will be logged too")
+}
@@ -0,0 +1,298 @@
+// (c) 2022 Jacek Olszak
+// This code is licensed under MIT license (see LICENSE for details)
+
+package levelfilter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/elgopher/yala/logger"
+)
+
+// Handler is a http.Handler which reads and changes levels at runtime, similar to zap AtomicLevel ServeHTTP.
+//
+// GET request returns the Level, levels of all Names and Rules in JSON:
+//
+//	{"level":"INFO","names":{"db":"WARN"},"rules":"payments.db=DEBUG,*.pool=WARN"}
+//
+// PUT request changes the level. The body can be either JSON or form values (application/x-www-form-urlencoded):
+//
+//	{"level":"debug","revertAfter":"10m"}
+//
+// Optional revertAfter is a duration after which the level is reverted to the value from before the change.
+//
+// Both requests use the level with given name, when the "name" query parameter is present, for example "?name=db".
+// Then the response contains only this level: {"level":"WARN"}.
+//
+// Both requests use Rules, when the "rules" query parameter is present. Then PUT request replaces all rules
+// (see Rules Set) and can revert them too:
+//
+//	{"rules":"payments.db=debug,*.pool=warn","revertAfter":"10m"}
+//
+// Handler must not be copied after first use.
+type Handler struct {
+	// Level is the default level.
+	Level *AtomicLevel
+	// Names contains overrides used by different loggers. Each logger (or Global) can use levelfilter.Adapter with
+	// its own level.
+	Names map[string]*AtomicLevel
+	// Rules are levels of named loggers used by levelfilter.Adapter (see Adapter Rules).
+	Rules *Rules
+	// AfterFunc runs f in its own goroutine after duration d. It returns a function stopping the timer. It is used
+	// to revert changes. When nil, time.AfterFunc is used.
+	AfterFunc func(d time.Duration, f func()) (stop func() bool)
+
+	mutex   sync.Mutex
+	reverts map[interface{}]*revert // pending reverts of *AtomicLevel or *Rules
+}
+
+type revert struct {
+	stopTimer func() bool
+	restore   func() // restores the value from before the first change
+}
+
+type levelsResponse struct {
+	Level logger.Level            `json:"level"`
+	Names map[string]logger.Level `json:"names,omitempty"`
+	Rules *string                 `json:"rules,omitempty"`
+}
+
+type rulesResponse struct {
+	Rules string `json:"rules"`
+}
+
+type changeRequest struct {
+	Level       *logger.Level `json:"level"`
+	Rules       *string       `json:"rules"`
+	RevertAfter string        `json:"revertAfter"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func (h *Handler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	if request.URL.Query().Has("rules") {
+		h.serveRules(writer, request)
+
+		return
+	}
+
+	level, name, err := h.levelToServe(request)
+	if err != nil {
+		writeJSON(writer, http.StatusNotFound, errorResponse{Error: err.Error()})
+
+		return
+	}
+
+	switch request.Method {
+	case http.MethodGet:
+		h.get(writer, level, name)
+	case http.MethodPut:
+		h.put(writer, request, level)
+	default:
+		methodNotAllowed(writer)
+	}
+}
+
+func (h *Handler) serveRules(writer http.ResponseWriter, request *http.Request) {
+	if h.Rules == nil {
+		writeJSON(writer, http.StatusNotFound, errorResponse{Error: "rules not configured"})
+
+		return
+	}
+
+	switch request.Method {
+	case http.MethodGet:
+		writeJSON(writer, http.StatusOK, rulesResponse{Rules: h.Rules.String()})
+	case http.MethodPut:
+		h.putRules(writer, request)
+	default:
+		methodNotAllowed(writer)
+	}
+}
+
+func (h *Handler) levelToServe(request *http.Request) (level *AtomicLevel, name string, err error) {
+	if !request.URL.Query().Has("name") {
+		if h.Level == nil {
+			return nil, "", errors.New("level not configured")
+		}
+
+		return h.Level, "", nil
+	}
+
+	name = request.URL.Query().Get("name")
+
+	level, ok := h.Names[name]
+	if !ok {
+		return nil, "", fmt.Errorf("unknown name %q", name)
+	}
+
+	return level, name, nil
+}
+
+func (h *Handler) get(writer http.ResponseWriter, level *AtomicLevel, name string) {
+	response := levelsResponse{Level: level.Level()}
+
+	if name == "" && len(h.Names) > 0 {
+		response.Names = make(map[string]logger.Level, len(h.Names))
+		for n, l := range h.Names {
+			response.Names[n] = l.Level()
+		}
+	}
+
+	if name == "" && h.Rules != nil {
+		rules := h.Rules.String()
+		response.Rules = &rules
+	}
+
+	writeJSON(writer, http.StatusOK, response)
+}
+
+func (h *Handler) put(writer http.ResponseWriter, request *http.Request, level *AtomicLevel) {
+	req, revertAfter, err := decodeChangeRequest(request)
+	if err == nil && req.Level == nil {
+		err = errors.New("level must be specified")
+	}
+
+	if err != nil {
+		writeJSON(writer, http.StatusBadRequest, errorResponse{Error: err.Error()})
+
+		return
+	}
+
+	newLevel := *req.Level
+
+	h.change(level, revertAfter, func() (restore func()) {
+		originalLevel := level.Level()
+		level.SetLevel(newLevel)
+
+		return func() { level.SetLevel(originalLevel) }
+	})
+
+	writeJSON(writer, http.StatusOK, levelsResponse{Level: newLevel})
+}
+
+func (h *Handler) putRules(writer http.ResponseWriter, request *http.Request) {
+	req, revertAfter, err := decodeChangeRequest(request)
+	if err == nil && req.Rules == nil {
+		err = errors.New("rules must be specified")
+	}
+
+	var newRules Rules
+	if err == nil {
+		err = newRules.Set(*req.Rules)
+	}
+
+	if err != nil {
+		writeJSON(writer, http.StatusBadRequest, errorResponse{Error: err.Error()})
+
+		return
+	}
+
+	h.change(h.Rules, revertAfter, func() (restore func()) {
+		originalRules := h.Rules.Rules()
+		h.Rules.SetRules(newRules.Rules()...)
+
+		return func() { h.Rules.SetRules(originalRules...) }
+	})
+
+	writeJSON(writer, http.StatusOK, rulesResponse{Rules: newRules.String()})
+}
+
+func decodeChangeRequest(request *http.Request) (req changeRequest, revertAfter time.Duration, err error) {
+	if strings.HasPrefix(request.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		if err = request.ParseForm(); err != nil {
+			return req, 0, err
+		}
+
+		if request.Form.Has("level") {
+			req.Level = new(logger.Level)
+			if err = req.Level.Set(request.Form.Get("level")); err != nil {
+				return req, 0, err
+			}
+		}
+
+		if request.Form.Has("rules") {
+			rules := request.Form.Get("rules")
+			req.Rules = &rules
+		}
+
+		req.RevertAfter = request.Form.Get("revertAfter")
+	} else if err = json.NewDecoder(request.Body).Decode(&req); err != nil {
+		return req, 0, fmt.Errorf("malformed request body: %w", err)
+	}
+
+	if req.RevertAfter != "" {
+		revertAfter, err = time.ParseDuration(req.RevertAfter)
+		if err != nil {
+			return req, 0, fmt.Errorf("invalid revertAfter: %w", err)
+		}
+	}
+
+	return req, revertAfter, nil
+}
+
+// change applies the change of target (*AtomicLevel or *Rules) and schedules revert, if revertAfter is positive.
+// Pending revert is canceled, but the original value is remembered, so the next revert restores the value from
+// before the first change.
+func (h *Handler) change(target interface{}, revertAfter time.Duration, apply func() (restore func())) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	restore := apply()
+
+	if pending, ok := h.reverts[target]; ok {
+		pending.stopTimer()
+		restore = pending.restore
+
+		delete(h.reverts, target)
+	}
+
+	if revertAfter <= 0 {
+		return
+	}
+
+	if h.reverts == nil {
+		h.reverts = map[interface{}]*revert{}
+	}
+
+	r := &revert{restore: restore}
+	r.stopTimer = h.afterFunc(revertAfter, func() {
+		h.mutex.Lock()
+		defer h.mutex.Unlock()
+
+		if h.reverts[target] != r { // revert was canceled
+			return
+		}
+
+		r.restore()
+		delete(h.reverts, target)
+	})
+	h.reverts[target] = r
+}
+
+func (h *Handler) afterFunc(d time.Duration, f func()) (stop func() bool) {
+	if h.AfterFunc == nil {
+		return time.AfterFunc(d, f).Stop
+	}
+
+	return h.AfterFunc(d, f)
+}
+
+func methodNotAllowed(writer http.ResponseWriter) {
+	writer.Header().Set("Allow", "GET, PUT")
+	writeJSON(writer, http.StatusMethodNotAllowed, errorResponse{Error: "only GET and PUT methods are supported"})
+}
+
+func writeJSON(writer http.ResponseWriter, status int, body interface{}) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(status)
+	_ = json.NewEncoder(writer).Encode(body)
+}
@@ -0,0 +1,311 @@
+// (c) 2022 Jacek Olszak
+// This code is licensed under MIT license (see LICENSE for details)
+
+package levelfilter_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/elgopher/yala/adapter/internal/fake"
+	"github.com/elgopher/yala/adapter/levelfilter"
+	"github.com/elgopher/yala/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	t.Run("GET should return levels", func(t *testing.T) {
+		handler := &levelfilter.Handler{
+			Level: levelfilter.NewAtomicLevel(logger.InfoLevel),
+			Names: map[string]*levelfilter.AtomicLevel{
+				"db": levelfilter.NewAtomicLevel(logger.WarnLevel),
+			},
+		}
+		// when
+		response := serve(handler, http.MethodGet, "/", "", "")
+		// then
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, "application/json", response.Header().Get("Content-Type"))
+		assert.JSONEq(t, `{"level":"INFO","names":{"db":"WARN"}}`, response.Body.String())
+	})
+
+	t.Run("GET should return levels with rules", func(t *testing.T) {
+		handler := &levelfilter.Handler{
+			Level: levelfilter.NewAtomicLevel(logger.InfoLevel),
+			Rules: levelfilter.NewRules(levelfilter.Rule{Pattern: "payments.db", Level: logger.DebugLevel}),
+		}
+		// when
+		response := serve(handler, http.MethodGet, "/", "", "")
+		// then
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, `{"level":"INFO","rules":"payments.db=DEBUG"}`, response.Body.String())
+	})
+
+	t.Run("GET should return rules", func(t *testing.T) {
+		handler := &levelfilter.Handler{
+			Rules: levelfilter.NewRules(
+				levelfilter.Rule{Pattern: "payments.db", Level: logger.DebugLevel},
+				levelfilter.Rule{Pattern: "*.pool", Level: logger.WarnLevel},
+			),
+		}
+		// when
+		response := serve(handler, http.MethodGet, "/?rules", "", "")
+		// then
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, `{"rules":"payments.db=DEBUG,*.pool=WARN"}`, response.Body.String())
+	})
+
+	t.Run("should return 404 when Rules is nil", func(t *testing.T) {
+		handler := &levelfilter.Handler{Level: levelfilter.NewAtomicLevel(logger.InfoLevel)}
+		// when
+		response := serve(handler, http.MethodGet, "/?rules", "", "")
+		// then
+		assert.Equal(t, http.StatusNotFound, response.Code)
+		assert.JSONEq(t, `{"error":"rules not configured"}`, response.Body.String())
+	})
+
+	t.Run("GET should return named level", func(t *testing.T) {
+		handler := &levelfilter.Handler{
+			Level: levelfilter.NewAtomicLevel(logger.InfoLevel),
+			Names: map[string]*levelfilter.AtomicLevel{
+				"db": levelfilter.NewAtomicLevel(logger.WarnLevel),
+			},
+		}
+		// when
+		response := serve(handler, http.MethodGet, "/?name=db", "", "")
+		// then
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, `{"level":"WARN"}`, response.Body.String())
+	})
+
+	t.Run("should return 404 for unknown name", func(t *testing.T) {
+		handler := &levelfilter.Handler{Level: levelfilter.NewAtomicLevel(logger.InfoLevel)}
+		// when
+		response := serve(handler, http.MethodGet, "/?name=unknown", "", "")
+		// then
+		assert.Equal(t, http.StatusNotFound, response.Code)
+		assert.JSONEq(t, `{"error":"unknown name \"unknown\""}`, response.Body.String())
+	})
+
+	t.Run("should return 404 when Level is nil", func(t *testing.T) {
+		handler := &levelfilter.Handler{}
+		// when
+		response := serve(handler, http.MethodGet, "/", "", "")
+		// then
+		assert.Equal(t, http.StatusNotFound, response.Code)
+	})
+
+	t.Run("should return 405 for unsupported method", func(t *testing.T) {
+		handler := &levelfilter.Handler{Level: levelfilter.NewAtomicLevel(logger.InfoLevel)}
+		// when
+		response := serve(handler, http.MethodPost, "/", "", "")
+		// then
+		assert.Equal(t, http.StatusMethodNotAllowed, response.Code)
+		assert.Equal(t, "GET, PUT", response.Header().Get("Allow"))
+	})
+
+	t.Run("PUT should change level", func(t *testing.T) {
+		tests := map[string]struct {
+			contentType string
+			body        string
+		}{
+			"json": {
+				body: `{"level":"debug"}`,
+			},
+			"form": {
+				contentType: "application/x-www-form-urlencoded",
+				body:        url.Values{"level": {"debug"}}.Encode(),
+			},
+		}
+
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				level := levelfilter.NewAtomicLevel(logger.InfoLevel)
+				handler := &levelfilter.Handler{Level: level}
+				// when
+				response := serve(handler, http.MethodPut, "/", test.contentType, test.body)
+				// then
+				assert.Equal(t, http.StatusOK, response.Code)
+				assert.JSONEq(t, `{"level":"DEBUG"}`, response.Body.String())
+				assert.Equal(t, logger.DebugLevel, level.Level())
+			})
+		}
+	})
+
+	t.Run("PUT should change named level", func(t *testing.T) {
+		level := levelfilter.NewAtomicLevel(logger.InfoLevel)
+		dbLevel := levelfilter.NewAtomicLevel(logger.InfoLevel)
+		handler := &levelfilter.Handler{
+			Level: level,
+			Names: map[string]*levelfilter.AtomicLevel{"db": dbLevel},
+		}
+		// when
+		response := serve(handler, http.MethodPut, "/?name=db", "", `{"level":"error"}`)
+		// then
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, logger.ErrorLevel, dbLevel.Level())
+		assert.Equal(t, logger.InfoLevel, level.Level())
+	})
+
+	t.Run("PUT should return 400 for invalid request", func(t *testing.T) {
+		tests := map[string]struct {
+			contentType string
+			body        string
+		}{
+			"malformed json":      {body: `{`},
+			"missing level":       {body: `{}`},
+			"invalid level":       {body: `{"level":"invalid"}`},
+			"invalid revertAfter": {body: `{"level":"debug","revertAfter":"invalid"}`},
+			"invalid form level": {
+				contentType: "application/x-www-form-urlencoded",
+				body:        url.Values{"level": {"invalid"}}.Encode(),
+			},
+			"missing form level": {
+				contentType: "application/x-www-form-urlencoded",
+				body:        "",
+			},
+		}
+
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				level := levelfilter.NewAtomicLevel(logger.InfoLevel)
+				handler := &levelfilter.Handler{Level: level}
+				// when
+				response := serve(handler, http.MethodPut, "/", test.contentType, test.body)
+				// then
+				assert.Equal(t, http.StatusBadRequest, response.Code)
+				assert.Contains(t, response.Body.String(), `"error"`)
+				assert.Equal(t, logger.InfoLevel, level.Level())
+			})
+		}
+	})
+
+	t.Run("PUT should replace rules", func(t *testing.T) {
+		tests := map[string]struct {
+			contentType string
+			body        string
+		}{
+			"json": {
+				body: `{"rules":"payments.db=debug,*.pool=warn"}`,
+			},
+			"form": {
+				contentType: "application/x-www-form-urlencoded",
+				body:        url.Values{"rules": {"payments.db=debug,*.pool=warn"}}.Encode(),
+			},
+		}
+
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				rules := levelfilter.NewRules(levelfilter.Rule{Pattern: "payments", Level: logger.InfoLevel})
+				handler := &levelfilter.Handler{Rules: rules}
+				// when
+				response := serve(handler, http.MethodPut, "/?rules", test.contentType, test.body)
+				// then
+				assert.Equal(t, http.StatusOK, response.Code)
+				assert.JSONEq(t, `{"rules":"payments.db=DEBUG,*.pool=WARN"}`, response.Body.String())
+				expectedRules := []levelfilter.Rule{
+					{Pattern: "payments.db", Level: logger.DebugLevel},
+					{Pattern: "*.pool", Level: logger.WarnLevel},
+				}
+				assert.Equal(t, expectedRules, rules.Rules())
+			})
+		}
+	})
+
+	t.Run("PUT should return 400 for invalid rules request", func(t *testing.T) {
+		tests := map[string]string{
+			"malformed json": `{`,
+			"missing rules":  `{"level":"debug"}`,
+			"invalid rules":  `{"rules":"payments.db"}`,
+			"invalid level":  `{"rules":"payments.db=invalid"}`,
+		}
+
+		for name, body := range tests {
+			t.Run(name, func(t *testing.T) {
+				originalRule := levelfilter.Rule{Pattern: "payments", Level: logger.InfoLevel}
+				rules := levelfilter.NewRules(originalRule)
+				handler := &levelfilter.Handler{Rules: rules}
+				// when
+				response := serve(handler, http.MethodPut, "/?rules", "", body)
+				// then
+				assert.Equal(t, http.StatusBadRequest, response.Code)
+				assert.Contains(t, response.Body.String(), `"error"`)
+				assert.Equal(t, []levelfilter.Rule{originalRule}, rules.Rules())
+			})
+		}
+	})
+
+	t.Run("PUT should revert rules after given duration", func(t *testing.T) {
+		originalRule := levelfilter.Rule{Pattern: "payments", Level: logger.InfoLevel}
+		rules := levelfilter.NewRules(originalRule)
+		clock := fake.NewClock(time.Time{})
+		handler := &levelfilter.Handler{Rules: rules, AfterFunc: clock.AfterFunc}
+		response := serve(handler, http.MethodPut, "/?rules", "", `{"rules":"payments=debug","revertAfter":"10m"}`)
+		require.Equal(t, http.StatusOK, response.Code)
+		require.Equal(t, []levelfilter.Rule{{Pattern: "payments", Level: logger.DebugLevel}}, rules.Rules())
+		// when
+		clock.Advance(10 * time.Minute)
+		// then
+		assert.Equal(t, []levelfilter.Rule{originalRule}, rules.Rules())
+	})
+
+	t.Run("PUT should revert level after given duration", func(t *testing.T) {
+		level := levelfilter.NewAtomicLevel(logger.InfoLevel)
+		clock := fake.NewClock(time.Time{})
+		handler := &levelfilter.Handler{Level: level, AfterFunc: clock.AfterFunc}
+		response := serve(handler, http.MethodPut, "/", "", `{"level":"debug","revertAfter":"10m"}`)
+		require.Equal(t, http.StatusOK, response.Code)
+		require.Equal(t, logger.DebugLevel, level.Level())
+		// when
+		clock.Advance(10*time.Minute - time.Nanosecond)
+		// then
+		assert.Equal(t, logger.DebugLevel, level.Level())
+		// and when
+		clock.Advance(time.Nanosecond)
+		// then
+		assert.Equal(t, logger.InfoLevel, level.Level())
+	})
+
+	t.Run("PUT should cancel pending revert", func(t *testing.T) {
+		level := levelfilter.NewAtomicLevel(logger.InfoLevel)
+		clock := fake.NewClock(time.Time{})
+		handler := &levelfilter.Handler{Level: level, AfterFunc: clock.AfterFunc}
+		serve(handler, http.MethodPut, "/", "", `{"level":"debug","revertAfter":"10m"}`)
+		// when
+		serve(handler, http.MethodPut, "/", "", `{"level":"warn"}`)
+		// then
+		assert.Zero(t, clock.Timers())
+		clock.Advance(time.Hour)
+		assert.Equal(t, logger.WarnLevel, level.Level())
+	})
+
+	t.Run("PUT should revert to the level from before the first change", func(t *testing.T) {
+		level := levelfilter.NewAtomicLevel(logger.InfoLevel)
+		clock := fake.NewClock(time.Time{})
+		handler := &levelfilter.Handler{Level: level, AfterFunc: clock.AfterFunc}
+		serve(handler, http.MethodPut, "/", "", `{"level":"debug","revertAfter":"1h"}`)
+		// when
+		serve(handler, http.MethodPut, "/", "", `{"level":"warn","revertAfter":"10m"}`)
+		// then
+		clock.Advance(10 * time.Minute)
+		assert.Equal(t, logger.InfoLevel, level.Level())
+		assert.Zero(t, clock.Timers())
+	})
+}
+
+func serve(handler http.Handler, method, target, contentType, body string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(method, target, strings.NewReader(body))
+	if contentType != "" {
+		request.Header.Set("Content-Type", contentType)
+	}
+
+	response := httptest.NewRecorder()
+	handler.ServeHTTP(response, request)
+
+	return response
+}
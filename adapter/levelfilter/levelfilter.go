@@ -0,0 +1,141 @@
+// (c) 2022 Jacek Olszak
+// This code is licensed under MIT license (see LICENSE for details)
+
+// Package levelfilter provides middleware adapter filtering entries by level, which can be changed at runtime.
+package levelfilter
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/elgopher/yala/logger"
+)
+
+// AtomicLevel is a logger.Level which can be safely read and changed concurrently. Zero value is logger.InfoLevel.
+//
+// AtomicLevel implements flag.Value, encoding.TextMarshaler and encoding.TextUnmarshaler, so it can be set using
+// command-line flag or unmarshalled from configuration file.
+type AtomicLevel struct {
+	level atomic.Int32
+}
+
+// NewAtomicLevel creates AtomicLevel with given level.
+func NewAtomicLevel(level logger.Level) *AtomicLevel {
+	a := &AtomicLevel{}
+	a.SetLevel(level)
+
+	return a
+}
+
+// Level returns the current level.
+func (a *AtomicLevel) Level() logger.Level {
+	return logger.Level(a.level.Load())
+}
+
+// SetLevel changes the level.
+func (a *AtomicLevel) SetLevel(level logger.Level) {
+	a.level.Store(int32(level))
+}
+
+// String returns the current level as a string. See logger.Level String.
+func (a *AtomicLevel) String() string {
+	return a.Level().String()
+}
+
+// Set changes the level parsed using logger.ParseLevel.
+func (a *AtomicLevel) Set(s string) error {
+	level, err := logger.ParseLevel(s)
+	if err != nil {
+		return err
+	}
+
+	a.SetLevel(level)
+
+	return nil
+}
+
+// MarshalText converts the current level to text. See logger.Level MarshalText.
+func (a *AtomicLevel) MarshalText() ([]byte, error) {
+	return a.Level().MarshalText()
+}
+
+// UnmarshalText changes the level parsed using logger.ParseLevel.
+func (a *AtomicLevel) UnmarshalText(text []byte) error {
+	return a.Set(string(text))
+}
+
+// Adapter is a middleware (decorator) adapter which filters out entries with level less severe than Level.
+// The level can be different for named loggers (see logger.Logger Named), using Rules.
+type Adapter struct {
+	NextAdapter logger.Adapter
+	// Level is the least severe level passed to the next adapter. When nil, logger.InfoLevel is used.
+	Level *AtomicLevel
+	// Rules are optional rules overriding the Level for entries with matching names.
+	Rules *Rules
+}
+
+// Log passes the entry to the next adapter, if its level is enabled.
+func (a Adapter) Log(ctx context.Context, entry logger.Entry) {
+	if a.NextAdapter == nil || a.minLevelFor(entry.Name).MoreSevereThan(entry.Level) {
+		return
+	}
+
+	entry.SkippedCallerFrames++ // each middleware adapter must additionally skip one frame
+
+	a.NextAdapter.Log(ctx, entry)
+}
+
+func (a Adapter) minLevel() logger.Level {
+	if a.Level == nil {
+		return logger.InfoLevel
+	}
+
+	return a.Level.Level()
+}
+
+func (a Adapter) minLevelFor(name string) logger.Level {
+	if a.Rules != nil {
+		if level, ok := a.Rules.Level(name); ok {
+			return level
+		}
+	}
+
+	return a.minLevel()
+}
+
+// Enabled returns false if the level is less severe than Level and levels of all Rules (the name of the logger is not
+// known yet). Otherwise, it passes the check to the next adapter. See logger.LevelEnabler.
+func (a Adapter) Enabled(ctx context.Context, level logger.Level) bool {
+	if a.NextAdapter == nil {
+		return false
+	}
+
+	minLevel := a.minLevel()
+
+	if a.Rules != nil {
+		if rulesLevel, ok := a.Rules.leastSevereLevel(); ok && minLevel.MoreSevereThan(rulesLevel) {
+			minLevel = rulesLevel
+		}
+	}
+
+	if minLevel.MoreSevereThan(level) {
+		return false
+	}
+
+	return logger.Enabled(ctx, a.NextAdapter, level)
+}
+
+// ReportsCaller passes the check to the next adapter. See logger.CallerReporter.
+func (a Adapter) ReportsCaller() bool {
+	return logger.ReportsCaller(a.NextAdapter)
+}
+
+// Sync passes the call to the next adapter. See logger.Syncer.
+func (a Adapter) Sync() error {
+	return logger.Sync(a.NextAdapter)
+}
+
+// Close passes the call to the next adapter. See logger.Closer.
+func (a Adapter) Close() error {
+	return logger.Close(a.NextAdapter)
+}
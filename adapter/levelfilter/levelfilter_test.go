@@ -0,0 +1,168 @@
+// (c) 2022 Jacek Olszak
+// This code is licensed under MIT license (see LICENSE for details)
+
+package levelfilter_test
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"testing"
+
+	"github.com/elgopher/yala/adapter/internal/adaptertest"
+	"github.com/elgopher/yala/adapter/internal/fake"
+	"github.com/elgopher/yala/adapter/levelfilter"
+	"github.com/elgopher/yala/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const message = "message"
+
+var ctx = context.Background()
+
+func TestAtomicLevel(t *testing.T) {
+	t.Run("zero value should be InfoLevel", func(t *testing.T) {
+		var level levelfilter.AtomicLevel
+		assert.Equal(t, logger.InfoLevel, level.Level())
+	})
+
+	t.Run("should change level", func(t *testing.T) {
+		level := levelfilter.NewAtomicLevel(logger.WarnLevel)
+		assert.Equal(t, logger.WarnLevel, level.Level())
+		// when
+		level.SetLevel(logger.DebugLevel)
+		// then
+		assert.Equal(t, logger.DebugLevel, level.Level())
+		assert.Equal(t, "DEBUG", level.String())
+	})
+
+	t.Run("should set level using command-line flag", func(t *testing.T) {
+		level := levelfilter.NewAtomicLevel(logger.InfoLevel)
+		flags := flag.NewFlagSet("test", flag.ContinueOnError)
+		flags.Var(level, "log-level", "")
+		// when
+		err := flags.Parse([]string{"-log-level=error"})
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, logger.ErrorLevel, level.Level())
+	})
+
+	t.Run("should not change level when string is invalid", func(t *testing.T) {
+		level := levelfilter.NewAtomicLevel(logger.WarnLevel)
+		// when
+		err := level.Set("invalid")
+		// then
+		assert.Error(t, err)
+		assert.Equal(t, logger.WarnLevel, level.Level())
+	})
+
+	t.Run("should marshal and unmarshal JSON", func(t *testing.T) {
+		level := levelfilter.NewAtomicLevel(logger.WarnLevel)
+		bytes, err := json.Marshal(level)
+		require.NoError(t, err)
+		assert.Equal(t, `"WARN"`, string(bytes))
+		// when
+		err = json.Unmarshal([]byte(`"debug"`), level)
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, logger.DebugLevel, level.Level())
+	})
+}
+
+func TestAdapter_Log(t *testing.T) {
+	t.Run("should not panic when next adapter is nil", func(t *testing.T) {
+		adapter := levelfilter.Adapter{}
+		assert.NotPanics(t, func() {
+			adapter.Log(ctx, logger.Entry{Level: logger.InfoLevel, Message: message})
+		})
+	})
+
+	t.Run("should filter out entries with level less severe than Level", func(t *testing.T) {
+		next := &fake.Adapter{}
+		adapter := levelfilter.Adapter{NextAdapter: next, Level: levelfilter.NewAtomicLevel(logger.WarnLevel)}
+		// when
+		adapter.Log(ctx, logger.Entry{Level: logger.InfoLevel, Message: "info"})
+		adapter.Log(ctx, logger.Entry{Level: logger.WarnLevel, Message: "warn"})
+		adapter.Log(ctx, logger.Entry{Level: logger.ErrorLevel, Message: "error"})
+		// then
+		entries := next.Entries()
+		require.Len(t, entries, 2)
+		assert.Equal(t, "warn", entries[0].Message)
+		assert.Equal(t, "error", entries[1].Message)
+	})
+
+	t.Run("should use InfoLevel when Level is nil", func(t *testing.T) {
+		next := &fake.Adapter{}
+		adapter := levelfilter.Adapter{NextAdapter: next}
+		// when
+		adapter.Log(ctx, logger.Entry{Level: logger.DebugLevel, Message: message})
+		adapter.Log(ctx, logger.Entry{Level: logger.InfoLevel, Message: message})
+		// then
+		assert.Len(t, next.Entries(), 1)
+	})
+
+	t.Run("should use level changed at runtime", func(t *testing.T) {
+		next := &fake.Adapter{}
+		level := levelfilter.NewAtomicLevel(logger.WarnLevel)
+		adapter := levelfilter.Adapter{NextAdapter: next, Level: level}
+		// when
+		level.SetLevel(logger.DebugLevel)
+		adapter.Log(ctx, logger.Entry{Level: logger.DebugLevel, Message: message})
+		// then
+		assert.Len(t, next.Entries(), 1)
+	})
+
+	t.Run("should use level of the rule matching entry name", func(t *testing.T) {
+		next := &fake.Adapter{}
+		adapter := levelfilter.Adapter{
+			NextAdapter: next,
+			Level:       levelfilter.NewAtomicLevel(logger.WarnLevel),
+			Rules:       levelfilter.NewRules(levelfilter.Rule{Pattern: "payments.db", Level: logger.DebugLevel}),
+		}
+		// when
+		adapter.Log(ctx, logger.Entry{Level: logger.DebugLevel, Message: "db", Name: "payments.db.pool"})
+		adapter.Log(ctx, logger.Entry{Level: logger.InfoLevel, Message: "api", Name: "payments.api"})
+		// then
+		entries := next.Entries()
+		require.Len(t, entries, 1)
+		assert.Equal(t, "db", entries[0].Message)
+	})
+
+	t.Run("should skip one more caller frame", func(t *testing.T) {
+		next := &fake.Adapter{}
+		adapter := levelfilter.Adapter{NextAdapter: next}
+		// when
+		adapter.Log(ctx, logger.Entry{Level: logger.InfoLevel, Message: message, SkippedCallerFrames: 1})
+		// then
+		entries := next.Entries()
+		require.Len(t, entries, 1)
+		assert.Equal(t, 2, entries[0].SkippedCallerFrames)
+	})
+}
+
+func TestAdapter_Enabled(t *testing.T) {
+	t.Run("should return false when level is less severe than Level", func(t *testing.T) {
+		adapter := levelfilter.Adapter{NextAdapter: &fake.Adapter{}, Level: levelfilter.NewAtomicLevel(logger.WarnLevel)}
+		assert.False(t, adapter.Enabled(ctx, logger.InfoLevel))
+		assert.True(t, adapter.Enabled(ctx, logger.WarnLevel))
+	})
+
+	t.Run("should return true when level is enabled by any rule", func(t *testing.T) {
+		adapter := levelfilter.Adapter{
+			NextAdapter: &fake.Adapter{},
+			Level:       levelfilter.NewAtomicLevel(logger.WarnLevel),
+			Rules:       levelfilter.NewRules(levelfilter.Rule{Pattern: "payments", Level: logger.InfoLevel}),
+		}
+		assert.False(t, adapter.Enabled(ctx, logger.DebugLevel))
+		assert.True(t, adapter.Enabled(ctx, logger.InfoLevel))
+	})
+}
+
+func TestAdapter_Middleware(t *testing.T) {
+	adaptertest.RunMiddleware(t, adaptertest.Middleware{
+		NewAdapter: func(next logger.Adapter) logger.Adapter {
+			return levelfilter.Adapter{NextAdapter: next, Level: levelfilter.NewAtomicLevel(logger.DebugLevel)}
+		},
+	})
+}
@@ -0,0 +1,153 @@
+// (c) 2022 Jacek Olszak
+// This code is licensed under MIT license (see LICENSE for details)
+
+package levelfilter
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"sync/atomic"
+
+	"github.com/elgopher/yala/logger"
+)
+
+// Rule sets the least severe level for loggers with names matching the Pattern. See logger.Entry Name.
+type Rule struct {
+	// Pattern is either a name prefix or a glob pattern (see path.Match). For example, "payments.db" matches
+	// "payments.db" and "payments.db.pool", but not "payments.dbx". "*.pool" matches "payments.db.pool". Empty
+	// Pattern matches all names, including empty one.
+	Pattern string
+	Level   logger.Level
+}
+
+func (r Rule) matches(name string) bool {
+	if strings.ContainsAny(r.Pattern, `*?[\`) {
+		matched, _ := path.Match(r.Pattern, name)
+
+		return matched
+	}
+
+	return r.Pattern == "" || name == r.Pattern || strings.HasPrefix(name, r.Pattern+".")
+}
+
+// String returns the rule in format "pattern=LEVEL".
+func (r Rule) String() string {
+	return r.Pattern + "=" + r.Level.String()
+}
+
+// Rules is a set of rules which can be safely read and changed concurrently. Zero value has no rules.
+//
+// Rules implements flag.Value, so it can be set using command-line flag, for example
+// "-log-rules=payments.db=debug,*.pool=warn".
+type Rules struct {
+	rules atomic.Pointer[[]Rule]
+}
+
+// NewRules creates Rules with given rules.
+func NewRules(rules ...Rule) *Rules {
+	r := &Rules{}
+	r.SetRules(rules...)
+
+	return r
+}
+
+// SetRules replaces all rules.
+func (r *Rules) SetRules(rules ...Rule) {
+	rulesCopy := append([]Rule(nil), rules...)
+	r.rules.Store(&rulesCopy)
+}
+
+// Rules returns a copy of all rules.
+func (r *Rules) Rules() []Rule {
+	rules := r.rules.Load()
+	if rules == nil {
+		return nil
+	}
+
+	return append([]Rule(nil), *rules...)
+}
+
+// Level returns the level of the most specific rule matching the name, that is the rule with the longest Pattern.
+// When multiple such rules exist, the last one is used. It returns false, if no rule matches the name.
+func (r *Rules) Level(name string) (level logger.Level, matched bool) {
+	rules := r.rules.Load()
+	if rules == nil {
+		return 0, false
+	}
+
+	longestPattern := -1
+
+	for _, rule := range *rules {
+		if len(rule.Pattern) >= longestPattern && rule.matches(name) {
+			level = rule.Level
+			longestPattern = len(rule.Pattern)
+			matched = true
+		}
+	}
+
+	return level, matched
+}
+
+// leastSevereLevel returns the least severe level of all rules. It returns false, if there are no rules.
+func (r *Rules) leastSevereLevel() (level logger.Level, ok bool) {
+	rules := r.rules.Load()
+	if rules == nil || len(*rules) == 0 {
+		return 0, false
+	}
+
+	level = (*rules)[0].Level
+
+	for _, rule := range *rules {
+		if level.MoreSevereThan(rule.Level) {
+			level = rule.Level
+		}
+	}
+
+	return level, true
+}
+
+// String returns comma-separated rules, for example "payments.db=DEBUG,*.pool=WARN".
+func (r *Rules) String() string {
+	if r == nil {
+		return ""
+	}
+
+	rules := r.Rules()
+
+	s := make([]string, len(rules))
+	for i, rule := range rules {
+		s[i] = rule.String()
+	}
+
+	return strings.Join(s, ",")
+}
+
+// Set replaces all rules with rules parsed from comma-separated list, for example "payments.db=debug,*.pool=warn".
+// Levels are parsed using logger.ParseLevel.
+func (r *Rules) Set(s string) error {
+	var rules []Rule
+
+	for _, rule := range strings.Split(s, ",") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+
+		separator := strings.LastIndex(rule, "=")
+		if separator < 0 {
+			return fmt.Errorf("invalid rule %q: missing level", rule)
+		}
+
+		level, err := logger.ParseLevel(rule[separator+1:])
+		if err != nil {
+			return fmt.Errorf("invalid rule %q: %w", rule, err)
+		}
+
+		rules = append(rules, Rule{Pattern: rule[:separator], Level: level})
+	}
+
+	r.SetRules(rules...)
+
+	return nil
+}
@@ -0,0 +1,114 @@
+// (c) 2022 Jacek Olszak
+// This code is licensed under MIT license (see LICENSE for details)
+
+package levelfilter_test
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/elgopher/yala/adapter/levelfilter"
+	"github.com/elgopher/yala/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRules_Level(t *testing.T) {
+	t.Run("zero value should not match any name", func(t *testing.T) {
+		var rules levelfilter.Rules
+		_, matched := rules.Level("payments")
+		assert.False(t, matched)
+	})
+
+	t.Run("should match name", func(t *testing.T) {
+		tests := map[string]struct {
+			pattern string
+			name    string
+			matched bool
+		}{
+			"same name":                    {pattern: "payments.db", name: "payments.db", matched: true},
+			"child name":                   {pattern: "payments.db", name: "payments.db.pool", matched: true},
+			"name with the same prefix":    {pattern: "payments.db", name: "payments.dbx", matched: false},
+			"parent name":                  {pattern: "payments.db", name: "payments", matched: false},
+			"empty pattern":                {pattern: "", name: "payments", matched: true},
+			"empty pattern and empty name": {pattern: "", name: "", matched: true},
+			"glob":                         {pattern: "*.pool", name: "payments.pool", matched: true},
+			"glob with nested name":        {pattern: "payments.*.pool", name: "payments.db.pool", matched: true},
+			"glob not matching":            {pattern: "*.pool", name: "payments.db", matched: false},
+		}
+
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				rules := levelfilter.NewRules(levelfilter.Rule{Pattern: test.pattern, Level: logger.DebugLevel})
+				// when
+				level, matched := rules.Level(test.name)
+				// then
+				assert.Equal(t, test.matched, matched)
+				if test.matched {
+					assert.Equal(t, logger.DebugLevel, level)
+				}
+			})
+		}
+	})
+
+	t.Run("should use the most specific rule", func(t *testing.T) {
+		rules := levelfilter.NewRules(
+			levelfilter.Rule{Pattern: "payments.db", Level: logger.DebugLevel},
+			levelfilter.Rule{Pattern: "payments", Level: logger.ErrorLevel},
+			levelfilter.Rule{Pattern: "", Level: logger.WarnLevel},
+		)
+		// when
+		dbLevel, _ := rules.Level("payments.db.pool")
+		paymentsLevel, _ := rules.Level("payments.api")
+		otherLevel, _ := rules.Level("orders")
+		// then
+		assert.Equal(t, logger.DebugLevel, dbLevel)
+		assert.Equal(t, logger.ErrorLevel, paymentsLevel)
+		assert.Equal(t, logger.WarnLevel, otherLevel)
+	})
+
+	t.Run("should use rules changed at runtime", func(t *testing.T) {
+		rules := levelfilter.NewRules(levelfilter.Rule{Pattern: "payments", Level: logger.DebugLevel})
+		// when
+		rules.SetRules(levelfilter.Rule{Pattern: "payments", Level: logger.ErrorLevel})
+		// then
+		level, _ := rules.Level("payments")
+		assert.Equal(t, logger.ErrorLevel, level)
+	})
+}
+
+func TestRules_Set(t *testing.T) {
+	t.Run("should set rules using command-line flag", func(t *testing.T) {
+		rules := levelfilter.NewRules()
+		flags := flag.NewFlagSet("test", flag.ContinueOnError)
+		flags.Var(rules, "log-rules", "")
+		// when
+		err := flags.Parse([]string{"-log-rules=payments.db=debug, *.pool=warn"})
+		// then
+		require.NoError(t, err)
+		expected := []levelfilter.Rule{
+			{Pattern: "payments.db", Level: logger.DebugLevel},
+			{Pattern: "*.pool", Level: logger.WarnLevel},
+		}
+		assert.Equal(t, expected, rules.Rules())
+		assert.Equal(t, "payments.db=DEBUG,*.pool=WARN", rules.String())
+	})
+
+	t.Run("should return error for invalid rules", func(t *testing.T) {
+		tests := map[string]string{
+			"missing level": "payments",
+			"invalid level": "payments=invalid",
+		}
+
+		for name, s := range tests {
+			t.Run(name, func(t *testing.T) {
+				rules := levelfilter.NewRules(levelfilter.Rule{Pattern: "payments", Level: logger.DebugLevel})
+				// when
+				err := rules.Set(s)
+				// then
+				assert.Error(t, err)
+				assert.Len(t, rules.Rules(), 1)
+			})
+		}
+	})
+}
@@ -15,9 +15,9 @@ var ErrSome = errors.New("some error")
 func main() {
 	ctx := context.Background()
 
-	l := log15.New()                           // create log15 logger
-	adapter := log15adapter.Adapter{Logger: l} // create logger.Adapter for log15
-	log := logger.WithAdapter(adapter)         // create yala logger
+	l := log15.New()                      // create log15 logger
+	adapter := log15adapter.NewAdapter(l) // create logger.Adapter for log15
+	log := logger.WithAdapter(adapter)    // create yala logger
 
 	log.Debug(ctx, "Hello log15")
 
@@ -5,6 +5,7 @@ package log15adapter
 
 import (
 	"context"
+	"time"
 
 	"github.com/elgopher/yala/logger"
 	"github.com/inconshreveable/log15"
@@ -12,10 +13,28 @@ import (
 
 // Adapter is a logger.Adapter implementation, which is using `log15` package
 // (https://github.com/inconshreveable/log15).
+//
+// Please use NewAdapter to create an Adapter. Adapter created with a struct literal logs the current time
+// instead of the entry time.
 type Adapter struct {
 	Logger log15.Logger
+
+	timeLogger log15.Logger // child of Logger overriding the time of records, see NewAdapter
+}
+
+// NewAdapter returns an Adapter using log15Logger. Logger must not be changed afterwards.
+func NewAdapter(log15Logger log15.Logger) Adapter {
+	return Adapter{
+		Logger:     log15Logger,
+		timeLogger: newTimeLogger(log15Logger),
+	}
 }
 
+// entryTime is appended to the context of the record, along with its key, and then removed by the time logger.
+type entryTime time.Time
+
+type entryTimeKey struct{}
+
 // Log logs the entry using log15 package.
 func (a Adapter) Log(ctx context.Context, entry logger.Entry) {
 	if a.Logger == nil {
@@ -24,40 +43,94 @@ func (a Adapter) Log(ctx context.Context, entry logger.Entry) {
 
 	log15ctx := toCtx(entry)
 
+	log15Logger := a.Logger
+	if a.timeLogger != nil && !entry.Time.IsZero() {
+		log15Logger = a.timeLogger
+		log15ctx = append(log15ctx, entryTimeKey{}, entryTime(entry.Time))
+	}
+
 	switch entry.Level {
 	case logger.DebugLevel:
-		a.Logger.Debug(entry.Message, log15ctx...)
+		log15Logger.Debug(entry.Message, log15ctx...)
 	case logger.InfoLevel:
-		a.Logger.Info(entry.Message, log15ctx...)
+		log15Logger.Info(entry.Message, log15ctx...)
 	case logger.WarnLevel:
-		a.Logger.Warn(entry.Message, log15ctx...)
+		log15Logger.Warn(entry.Message, log15ctx...)
 	case logger.ErrorLevel:
-		a.Logger.Error(entry.Message, log15ctx...)
+		log15Logger.Error(entry.Message, log15ctx...)
 	default:
-		a.Logger.Info(entry.Message, log15ctx...)
+		log15Logger.Info(entry.Message, log15ctx...)
 	}
 }
 
+// newTimeLogger creates a child logger which sets the time of each record to entryTime found at the end of record
+// context. log15 always uses the current time, therefore the only way to use a different one is to modify the record
+// before passing it to the handler of the original logger.
+func newTimeLogger(log15Logger log15.Logger) log15.Logger { //nolint:ireturn
+	child := log15Logger.New()
+	child.SetHandler(log15.FuncHandler(func(r *log15.Record) error {
+		if n := len(r.Ctx); n >= 2 {
+			if t, ok := r.Ctx[n-1].(entryTime); ok {
+				r.Time = time.Time(t)
+				r.Ctx = r.Ctx[:n-2]
+			}
+		}
+
+		return log15Logger.GetHandler().Log(r)
+	}))
+
+	return child
+}
+
 func toCtx(entry logger.Entry) []interface{} {
 	entryError := entry.Error
 
 	const lengthOfField = 2
 
-	length := len(entry.Fields) * lengthOfField
+	length := (len(entry.Fields) + 2) * lengthOfField // one more for the name and one for the entry time
 	if entryError != nil {
-		length += lengthOfField
+		length += 2 * lengthOfField // error and its type
 	}
 
-	log15ctx := make([]interface{}, length)
+	log15ctx := make([]interface{}, 0, length)
 
-	for i, field := range entry.Fields {
-		log15ctx[i*lengthOfField] = field.Key
-		log15ctx[i*lengthOfField+1] = field.Value
+	if entry.Name != "" {
+		log15ctx = append(log15ctx, logger.NameKey, entry.Name)
+	}
+	prefix := "" // fields nested in groups have dotted keys, for example "group.key"
+
+	for _, field := range entry.Fields {
+		if field.Kind() == logger.KindGroup {
+			prefix += field.Key + "."
+
+			continue
+		}
+
+		log15ctx = append(log15ctx, prefix+field.Key, field.AnyValue())
 	}
 
 	if entryError != nil {
-		log15ctx[length-lengthOfField] = "error"
-		log15ctx[length-1] = entryError
+		log15ctx = appendErrorCtx(log15ctx, entryError)
+	}
+
+	return log15ctx
+}
+
+// appendErrorCtx appends the error, the type of its root cause ("errorType"), messages of errors joined in it
+// ("errorCauses") and their types ("errorCauseTypes").
+func appendErrorCtx(log15ctx []interface{}, err error) []interface{} {
+	log15ctx = append(log15ctx, "error", err, "errorType", logger.ErrorType(err))
+
+	if causes := logger.ErrorCauses(err); len(causes) > 0 {
+		messages := make([]string, len(causes))
+		types := make([]string, len(causes))
+
+		for i, cause := range causes {
+			messages[i] = cause.Error()
+			types[i] = logger.ErrorType(cause)
+		}
+
+		log15ctx = append(log15ctx, "errorCauses", messages, "errorCauseTypes", types)
 	}
 
 	return log15ctx
@@ -15,9 +15,7 @@ func BenchmarkLog15(b *testing.B) {
 	log15logger := log15.New()
 	log15logger.SetHandler(log15.StreamHandler(benchmark.DiscardWriter{}, log15.LogfmtFormat()))
 
-	adapter := log15adapter.Adapter{
-		Logger: log15logger,
-	}
+	adapter := log15adapter.NewAdapter(log15logger)
 
 	benchmark.Adapter(b, adapter)
 }
@@ -5,7 +5,11 @@ package log15adapter_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/elgopher/yala/adapter/log15adapter"
 	"github.com/elgopher/yala/logger"
@@ -19,6 +23,7 @@ const message = "message"
 func TestAdapter_Log(t *testing.T) {
 	ctx := context.Background()
 	err := stringError("err message")
+	errType := "log15adapter_test.stringError"
 
 	t.Run("should log message with proper severity level", func(t *testing.T) {
 		tests := map[logger.Level]log15.Lvl{
@@ -74,6 +79,19 @@ func TestAdapter_Log(t *testing.T) {
 					Ctx: []interface{}{"k1", "v1", "k2", "v2"},
 				},
 			},
+			"name": {
+				entry: logger.Entry{
+					Level:   logger.InfoLevel,
+					Message: message,
+					Name:    "payments.db",
+					Fields:  []logger.Field{{Key: "k", Value: "v"}},
+				},
+				expectedRecord: log15.Record{
+					Lvl: log15.LvlInfo,
+					Msg: message,
+					Ctx: []interface{}{logger.NameKey, "payments.db", "k", "v"},
+				},
+			},
 			"error": {
 				entry: logger.Entry{
 					Level:   logger.ErrorLevel,
@@ -83,7 +101,24 @@ func TestAdapter_Log(t *testing.T) {
 				expectedRecord: log15.Record{
 					Lvl: log15.LvlError,
 					Msg: message,
-					Ctx: []interface{}{"error", err},
+					Ctx: []interface{}{"error", err, "errorType", errType},
+				},
+			},
+			"joined errors": {
+				entry: logger.Entry{
+					Level:   logger.ErrorLevel,
+					Message: message,
+					Error:   errors.Join(err, fmt.Errorf("wrapped: %w", err)),
+				},
+				expectedRecord: log15.Record{
+					Lvl: log15.LvlError,
+					Msg: message,
+					Ctx: []interface{}{
+						"error", errors.Join(err, fmt.Errorf("wrapped: %w", err)),
+						"errorType", "*errors.joinError",
+						"errorCauses", []string{"err message", "wrapped: err message"},
+						"errorCauseTypes", []string{errType, errType},
+					},
 				},
 			},
 			"field and error": {
@@ -96,7 +131,26 @@ func TestAdapter_Log(t *testing.T) {
 				expectedRecord: log15.Record{
 					Lvl: log15.LvlError,
 					Msg: message,
-					Ctx: []interface{}{"k", "v", "error", err},
+					Ctx: []interface{}{"k", "v", "error", err, "errorType", errType},
+				},
+			},
+			"fields nested in groups": {
+				entry: logger.Entry{
+					Level:   logger.ErrorLevel,
+					Message: message,
+					Fields: []logger.Field{
+						{Key: "k1", Value: "v1"},
+						logger.Group("http"),
+						{Key: "status", Value: 200},
+						logger.Group("db"),
+						{Key: "k2", Value: "v2"},
+					},
+					Error: stringError("err message"),
+				},
+				expectedRecord: log15.Record{
+					Lvl: log15.LvlError,
+					Msg: message,
+					Ctx: []interface{}{"k1", "v1", "http.status", 200, "http.db.k2", "v2", "error", err, "errorType", errType},
 				},
 			},
 			"fields and error": {
@@ -109,7 +163,7 @@ func TestAdapter_Log(t *testing.T) {
 				expectedRecord: log15.Record{
 					Lvl: log15.LvlError,
 					Msg: message,
-					Ctx: []interface{}{"k1", "v1", "k2", "v2", "error", err},
+					Ctx: []interface{}{"k1", "v1", "k2", "v2", "error", err, "errorType", errType},
 				},
 			},
 		}
@@ -129,6 +183,98 @@ func TestAdapter_Log(t *testing.T) {
 		}
 	})
 
+	t.Run("should log secret as redacted", func(t *testing.T) {
+		formats := map[string]log15.Format{
+			"logfmt": log15.LogfmtFormat(),
+			"json":   log15.JsonFormat(),
+		}
+
+		for name, format := range formats {
+			t.Run(name, func(t *testing.T) {
+				var builder strings.Builder
+				log15Logger := log15.New()
+				log15Logger.SetHandler(log15.StreamHandler(&builder, format))
+				adapter := log15adapter.Adapter{Logger: log15Logger}
+				// when
+				adapter.Log(ctx, logger.Entry{
+					Level:   logger.InfoLevel,
+					Message: message,
+					Fields:  []logger.Field{logger.Any("password", logger.NewSecret("pass"))},
+				})
+				// then
+				assert.Contains(t, builder.String(), logger.Redacted)
+			})
+		}
+	})
+
+	t.Run("should log entry time", func(t *testing.T) {
+		log15Logger := log15.New()
+		handler := &handlerMock{}
+		log15Logger.SetHandler(handler)
+		adapter := log15adapter.NewAdapter(log15Logger)
+		entryTime := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+		// when
+		adapter.Log(ctx, logger.Entry{Level: logger.InfoLevel, Message: message, Time: entryTime})
+		// then
+		require.Len(t, handler.records, 1)
+		assert.Equal(t, entryTime, handler.records[0].Time)
+		assert.Empty(t, handler.records[0].Ctx)
+	})
+
+	t.Run("should log current time when adapter was created with struct literal", func(t *testing.T) {
+		log15Logger := log15.New()
+		handler := &handlerMock{}
+		log15Logger.SetHandler(handler)
+		adapter := log15adapter.Adapter{Logger: log15Logger}
+		entryTime := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+		// when
+		adapter.Log(ctx, logger.Entry{Level: logger.InfoLevel, Message: message, Time: entryTime})
+		// then
+		require.Len(t, handler.records, 1)
+		assert.NotEqual(t, entryTime, handler.records[0].Time)
+		assert.Empty(t, handler.records[0].Ctx)
+	})
+
+	t.Run("should log current time when entry time is zero", func(t *testing.T) {
+		log15Logger := log15.New()
+		handler := &handlerMock{}
+		log15Logger.SetHandler(handler)
+		adapter := log15adapter.NewAdapter(log15Logger)
+		// when
+		adapter.Log(ctx, logger.Entry{Level: logger.InfoLevel, Message: message})
+		// then
+		require.Len(t, handler.records, 1)
+		assert.False(t, handler.records[0].Time.IsZero())
+	})
+
+	t.Run("should keep logger context when logging entry time", func(t *testing.T) {
+		log15Logger := log15.New("k1", "v1")
+		handler := &handlerMock{}
+		log15Logger.SetHandler(handler)
+		adapter := log15adapter.NewAdapter(log15Logger)
+		// when
+		adapter.Log(ctx, logger.Entry{
+			Level:   logger.InfoLevel,
+			Message: message,
+			Time:    time.Now(),
+			Fields:  []logger.Field{logger.String("k2", "v2")},
+		})
+		// then
+		require.Len(t, handler.records, 1)
+		assert.Equal(t, []interface{}{"k1", "v1", "k2", "v2"}, handler.records[0].Ctx)
+	})
+
+	t.Run("should use handler set after creating adapter", func(t *testing.T) {
+		log15Logger := log15.New()
+		adapter := log15adapter.NewAdapter(log15Logger)
+		handler := &handlerMock{}
+		log15Logger.SetHandler(handler)
+		// when
+		adapter.Log(ctx, logger.Entry{Level: logger.InfoLevel, Message: message, Time: time.Now()})
+		// then
+		assert.Len(t, handler.records, 1)
+	})
+
 	t.Run("should not panic when logger is nil", func(t *testing.T) {
 		adapter := log15adapter.Adapter{Logger: nil}
 		assert.NotPanics(t, func() {
@@ -3,27 +3,123 @@ package logadapter
 import (
 	"context"
 	"log"
+	"runtime"
+	"strconv"
+	"sync"
+	"time"
 
 	"github.com/elgopher/yala/adapter/printer"
 	"github.com/elgopher/yala/logger"
 )
 
+// Adapter returns a logger.Adapter printing entries using standard log.Logger. When l is configured to print
+// the file (log.Lshortfile or log.Llongfile flag), the caller of the entry is printed (see logger.Entry.Caller),
+// even when the entry was passed to another goroutine, for example by async adapter. When l is configured to print
+// the time, the time of the entry is printed instead of the current time. Such lines are formatted by the adapter
+// and written to l.Writer directly.
 func Adapter(l *log.Logger) logger.Adapter {
 	if l == nil {
 		return noopAdapter{}
 	}
 
-	return printer.Adapter{Printer: printerLogger{l}}
+	return printer.Adapter{Printer: printerLogger{Logger: l, mutex: &sync.Mutex{}}}
 }
 
 type printerLogger struct {
 	*log.Logger
+	mutex *sync.Mutex // serializes lines written to l.Writer directly
 }
 
 func (p printerLogger) Println(skipCallerFrames int, msg string) {
 	_ = p.Logger.Output(skipCallerFrames+2, msg) //nolint
 }
 
+func (p printerLogger) PrintlnEntry(entry logger.Entry, msg string) {
+	flags := p.Flags()
+	printsFile := flags&(log.Lshortfile|log.Llongfile) != 0
+	printsTime := flags&(log.Ldate|log.Ltime|log.Lmicroseconds) != 0
+	frame, callerFound := entry.Caller()
+
+	if (!printsFile || !callerFound) && (!printsTime || entry.Time.IsZero()) {
+		_ = p.Logger.Output(entry.SkippedCallerFrames+2, msg) //nolint
+
+		return
+	}
+
+	entryTime := entry.Time
+	if entryTime.IsZero() {
+		entryTime = time.Now()
+	}
+
+	if printsFile && !callerFound {
+		frame.File, frame.Line = "???", 0
+		if _, file, line, ok := runtime.Caller(entry.SkippedCallerFrames + 1); ok {
+			frame.File, frame.Line = file, line
+		}
+	}
+
+	line := appendHeader(nil, p.Prefix(), flags, entryTime, frame.File, frame.Line)
+	line = append(line, msg...)
+	line = append(line, '\n')
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	_, _ = p.Writer().Write(line)
+}
+
+// ReportsCaller returns true when the file is printed. See logger.CallerReporter.
+func (p printerLogger) ReportsCaller() bool {
+	return p.Flags()&(log.Lshortfile|log.Llongfile) != 0
+}
+
+// appendHeader appends the header in the same format as log.Logger does.
+func appendHeader(buf []byte, prefix string, flags int, t time.Time, file string, line int) []byte {
+	if flags&log.Lmsgprefix == 0 {
+		buf = append(buf, prefix...)
+	}
+
+	if flags&log.LUTC != 0 {
+		t = t.UTC()
+	}
+
+	if flags&log.Ldate != 0 {
+		buf = t.AppendFormat(buf, "2006/01/02 ")
+	}
+
+	if flags&(log.Ltime|log.Lmicroseconds) != 0 {
+		layout := "15:04:05 "
+		if flags&log.Lmicroseconds != 0 {
+			layout = "15:04:05.000000 "
+		}
+
+		buf = t.AppendFormat(buf, layout)
+	}
+
+	if flags&(log.Lshortfile|log.Llongfile) != 0 {
+		if flags&log.Lshortfile != 0 {
+			for i := len(file) - 1; i > 0; i-- {
+				if file[i] == '/' {
+					file = file[i+1:]
+
+					break
+				}
+			}
+		}
+
+		buf = append(buf, file...)
+		buf = append(buf, ':')
+		buf = strconv.AppendInt(buf, int64(line), 10)
+		buf = append(buf, ": "...)
+	}
+
+	if flags&log.Lmsgprefix != 0 {
+		buf = append(buf, prefix...)
+	}
+
+	return buf
+}
+
 type noopAdapter struct{}
 
 func (n noopAdapter) Log(context.Context, logger.Entry) {}
@@ -5,7 +5,12 @@ package logadapter_test
 
 import (
 	"context"
+	"fmt"
+	"log"
+	"runtime"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/elgopher/yala/adapter/logadapter"
 	"github.com/elgopher/yala/logger"
@@ -26,4 +31,129 @@ func TestAdapter_Log(t *testing.T) {
 			})
 		})
 	})
+
+	t.Run("should print caller", func(t *testing.T) {
+		var builder strings.Builder
+		yalaLogger := logger.WithAdapter(logadapter.Adapter(log.New(&builder, "", log.Lshortfile)))
+		// when
+		yalaLogger.Info(ctx, message)
+		_, _, line, _ := runtime.Caller(0)
+		// then
+		assert.Equal(t, fmt.Sprintf("logadapter_test.go:%d: INFO message\n", line-1), builder.String())
+	})
+
+	t.Run("should print caller from entry PC", func(t *testing.T) {
+		var pcs [1]uintptr
+		runtime.Callers(1, pcs[:])
+		_, file, line, _ := runtime.Caller(0)
+
+		tests := map[string]struct {
+			prefix   string
+			flags    int
+			expected string
+		}{
+			"short file": {
+				flags:    log.Lshortfile,
+				expected: fmt.Sprintf("logadapter_test.go:%d: INFO message\n", line-1),
+			},
+			"long file": {
+				flags:    log.Llongfile,
+				expected: fmt.Sprintf("%s:%d: INFO message\n", file, line-1),
+			},
+			"prefix": {
+				prefix:   "prefix ",
+				flags:    log.Lshortfile,
+				expected: fmt.Sprintf("prefix logadapter_test.go:%d: INFO message\n", line-1),
+			},
+			"message prefix": {
+				prefix:   "prefix ",
+				flags:    log.Lshortfile | log.Lmsgprefix,
+				expected: fmt.Sprintf("logadapter_test.go:%d: prefix INFO message\n", line-1),
+			},
+		}
+
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				var builder strings.Builder
+				adapter := logadapter.Adapter(log.New(&builder, test.prefix, test.flags))
+				// when
+				adapter.Log(ctx, logger.Entry{
+					Level:               logger.InfoLevel,
+					Message:             message,
+					PC:                  pcs[0],
+					SkippedCallerFrames: 100, // should be ignored
+				})
+				// then
+				assert.Equal(t, test.expected, builder.String())
+			})
+		}
+	})
+
+	t.Run("should print time in the same format as log.Logger", func(t *testing.T) {
+		var pcs [1]uintptr
+		runtime.Callers(1, pcs[:])
+
+		var builder strings.Builder
+		adapter := logadapter.Adapter(log.New(&builder, "", log.LstdFlags|log.Lmicroseconds|log.Lshortfile))
+		// when
+		adapter.Log(ctx, logger.Entry{Level: logger.InfoLevel, Message: message, PC: pcs[0]})
+		// then
+		header, _, found := strings.Cut(builder.String(), " logadapter_test.go:")
+		assert.True(t, found)
+		assert.Regexp(t, `^\d{4}/\d{2}/\d{2} \d{2}:\d{2}:\d{2}\.\d{6}$`, header)
+	})
+}
+
+func TestAdapter_Log_Time(t *testing.T) {
+	ctx := context.Background()
+	entryTime := time.Date(2022, 1, 2, 3, 4, 5, 6000, time.UTC)
+
+	t.Run("should print entry time", func(t *testing.T) {
+		tests := map[string]struct {
+			flags    int
+			expected string
+		}{
+			"date and time": {
+				flags:    log.LstdFlags | log.LUTC,
+				expected: "2022/01/02 03:04:05 INFO message\n",
+			},
+			"microseconds": {
+				flags:    log.Lmicroseconds | log.LUTC,
+				expected: "03:04:05.000006 INFO message\n",
+			},
+		}
+
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				var builder strings.Builder
+				adapter := logadapter.Adapter(log.New(&builder, "", test.flags))
+				// when
+				adapter.Log(ctx, logger.Entry{Level: logger.InfoLevel, Message: message, Time: entryTime})
+				// then
+				assert.Equal(t, test.expected, builder.String())
+			})
+		}
+	})
+
+	t.Run("should print entry time and caller found using skipped caller frames", func(t *testing.T) {
+		var builder strings.Builder
+		adapter := logadapter.Adapter(log.New(&builder, "", log.LstdFlags|log.LUTC|log.Lshortfile))
+		// when
+		adapter.Log(ctx, logger.Entry{Level: logger.InfoLevel, Message: message, Time: entryTime})
+		_, _, line, _ := runtime.Caller(0)
+		// then
+		expected := fmt.Sprintf("2022/01/02 03:04:05 logadapter_test.go:%d: INFO message\n", line-1)
+		assert.Equal(t, expected, builder.String())
+	})
+}
+
+func TestAdapter_ReportsCaller(t *testing.T) {
+	t.Run("should return true when file is printed", func(t *testing.T) {
+		assert.True(t, logger.ReportsCaller(logadapter.Adapter(log.New(&strings.Builder{}, "", log.Lshortfile))))
+		assert.True(t, logger.ReportsCaller(logadapter.Adapter(log.New(&strings.Builder{}, "", log.Llongfile))))
+	})
+
+	t.Run("should return false when file is not printed", func(t *testing.T) {
+		assert.False(t, logger.ReportsCaller(logadapter.Adapter(log.New(&strings.Builder{}, "", log.LstdFlags))))
+	})
 }
@@ -6,16 +6,50 @@ package logfmt
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/elgopher/yala/logger"
 )
 
-// WriteField converts the field to logfmt format (key=value) and appends it to the builder.
+// WriteField converts the field to logfmt format (key=value) and appends it to the builder. Field with
+// logger.KindGroup is not written at all, because it has no value. Please use WriteFields to write fields nested in
+// groups.
 func WriteField(builder *strings.Builder, field logger.Field) {
+	if field.Kind() == logger.KindGroup {
+		return
+	}
+
+	writeField(builder, "", field)
+}
+
+func writeField(builder *strings.Builder, prefix string, field logger.Field) {
+	builder.WriteString(prefix)
 	builder.WriteString(field.Key)
 	builder.WriteByte('=')
-	writeValue(builder, field.Value)
+
+	var buffer [32]byte // enough for any number
+
+	switch field.Kind() {
+	case logger.KindString:
+		writeString(builder, field.StringValue())
+	case logger.KindInt64:
+		builder.Write(strconv.AppendInt(buffer[:0], field.Int64Value(), 10))
+	case logger.KindUint64:
+		builder.Write(strconv.AppendUint(buffer[:0], field.Uint64Value(), 10))
+	case logger.KindFloat64:
+		builder.Write(strconv.AppendFloat(buffer[:0], field.Float64Value(), 'g', -1, 64))
+	case logger.KindBool:
+		builder.Write(strconv.AppendBool(buffer[:0], field.BoolValue()))
+	case logger.KindDuration:
+		builder.WriteString(field.DurationValue().String())
+	case logger.KindTime:
+		writeEscaped(builder, field.TimeValue().String())
+	case logger.KindAny, logger.KindError:
+		writeValue(builder, field.Value)
+	default:
+		writeValue(builder, field.AnyValue())
+	}
 }
 
 func writeValue(builder *strings.Builder, value interface{}) {
@@ -25,14 +59,26 @@ func writeValue(builder *strings.Builder, value interface{}) {
 		return
 	}
 
+	if s, ok := value.(string); ok {
+		writeString(builder, s)
+
+		return
+	}
+
+	writeEscaped(builder, fmt.Sprintf("%+v", value))
+}
+
+func writeString(builder *strings.Builder, value string) {
 	if value == "nil" {
 		builder.WriteString(`"nil"`)
 
 		return
 	}
 
-	valueStr := fmt.Sprintf("%+v", value)
+	writeEscaped(builder, value)
+}
 
+func writeEscaped(builder *strings.Builder, valueStr string) {
 	if strings.ContainsRune(valueStr, '\\') {
 		valueStr = strings.ReplaceAll(valueStr, `\`, `\\`)
 	}
@@ -54,14 +100,61 @@ func writeValue(builder *strings.Builder, value interface{}) {
 	}
 }
 
-// WriteFields writes multiple fields separated with spaces.
+// WriteFields writes multiple fields separated with spaces. Keys of fields nested in groups are prefixed with
+// dot-separated group names, for example "http.status=200".
 func WriteFields(builder *strings.Builder, fields []logger.Field) {
-	for i, f := range fields {
-		WriteField(builder, f)
+	prefix := ""
+	first := true
+
+	for _, f := range fields {
+		if f.Kind() == logger.KindGroup {
+			prefix += f.Key + "."
+
+			continue
+		}
 
-		notLast := i < len(fields)-1
-		if notLast {
+		if !first {
 			builder.WriteByte(' ')
 		}
+
+		writeField(builder, prefix, f)
+
+		first = false
+	}
+}
+
+// HasFieldsWithValue returns false if there are no fields or all of them are groups. It can be used to check
+// whether WriteFields writes anything.
+func HasFieldsWithValue(fields []logger.Field) bool {
+	for _, field := range fields {
+		if field.Kind() != logger.KindGroup {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WriteError writes the error message with the concrete type of its root cause (see logger.ErrorType). Errors joined
+// in err (for example using errors.Join) are written as separate fields with indexed keys, together with their types:
+//
+//	error="a, b" error.type=*fmt.wrapErrors error.0=a error.0.type=*errors.errorString error.1=b error.1.type=*MyError
+func WriteError(builder *strings.Builder, key string, err error) {
+	writeField(builder, "", logger.Field{Key: key, Value: err})
+
+	if err == nil {
+		return
+	}
+
+	builder.WriteByte(' ')
+	writeField(builder, key+".", logger.String("type", logger.ErrorType(err)))
+
+	for i, cause := range logger.ErrorCauses(err) {
+		causeKey := strconv.Itoa(i)
+
+		builder.WriteByte(' ')
+		writeField(builder, key+".", logger.Field{Key: causeKey, Value: cause})
+		builder.WriteByte(' ')
+		writeField(builder, key+"."+causeKey+".", logger.String("type", logger.ErrorType(cause)))
 	}
 }
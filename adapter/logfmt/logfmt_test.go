@@ -4,6 +4,8 @@
 package logfmt_test
 
 import (
+	"errors"
+	"fmt"
 	"strings"
 	"testing"
 	"time"
@@ -109,6 +111,50 @@ func TestWriteField(t *testing.T) {
 				),
 				expected: `k="{Property1:a Property2:b}"`,
 			},
+			"typed string": {
+				field:    logger.String("k", "v v"),
+				expected: `k="v v"`,
+			},
+			"typed nil string": {
+				field:    logger.String("k", "nil"),
+				expected: `k="nil"`,
+			},
+			"typed int64": {
+				field:    logger.Int64("k", -1),
+				expected: "k=-1",
+			},
+			"typed uint64": {
+				field:    logger.Uint64("k", 1),
+				expected: "k=1",
+			},
+			"typed float64": {
+				field:    logger.Float64("k", 2.1),
+				expected: "k=2.1",
+			},
+			"typed bool": {
+				field:    logger.Bool("k", true),
+				expected: "k=true",
+			},
+			"typed duration": {
+				field:    logger.Duration("k", time.Second),
+				expected: "k=1s",
+			},
+			"typed time": {
+				field:    logger.Time("k", time.Unix(0, 0).UTC()),
+				expected: `k="1970-01-01 00:00:00 +0000 UTC"`,
+			},
+			"typed error": {
+				field:    logger.Err("k", errors.New("some error")),
+				expected: `k="some error"`,
+			},
+			"group": {
+				field:    logger.Group("g"),
+				expected: "",
+			},
+			"typed nil error": {
+				field:    logger.Err("k", nil),
+				expected: "k=nil",
+			},
 		}
 		for name, test := range tests {
 			t.Run(name, func(t *testing.T) {
@@ -144,6 +190,20 @@ func TestWriteFields(t *testing.T) {
 				fields:   []logger.Field{field("k1", "v1"), field("k2", "v2"), field("k3", "v3")},
 				expected: "k1=v1 k2=v2 k3=v3",
 			},
+			"group": {
+				fields:   []logger.Field{field("k1", "v1"), logger.Group("http"), logger.Int("status", 200)},
+				expected: "k1=v1 http.status=200",
+			},
+			"nested groups": {
+				fields: []logger.Field{
+					logger.Group("http"), field("k1", "v1"), logger.Group("db"), field("k2", "v2"),
+				},
+				expected: "http.k1=v1 http.db.k2=v2",
+			},
+			"group without fields": {
+				fields:   []logger.Field{field("k", "v"), logger.Group("g")},
+				expected: "k=v",
+			},
 		}
 
 		for name, test := range tests {
@@ -157,3 +217,78 @@ func TestWriteFields(t *testing.T) {
 		}
 	})
 }
+
+func TestHasFieldsWithValue(t *testing.T) {
+	tests := map[string]struct {
+		fields   []logger.Field
+		expected bool
+	}{
+		"no fields": {
+			fields:   nil,
+			expected: false,
+		},
+		"only groups": {
+			fields:   []logger.Field{logger.Group("g1"), logger.Group("g2")},
+			expected: false,
+		},
+		"field": {
+			fields:   []logger.Field{field("k", "v")},
+			expected: true,
+		},
+		"field in group": {
+			fields:   []logger.Field{logger.Group("g"), field("k", "v")},
+			expected: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, logfmt.HasFieldsWithValue(test.fields))
+		})
+	}
+}
+
+func TestWriteError(t *testing.T) {
+	t.Run("should format error using logfmt", func(t *testing.T) {
+		errA := errors.New("a")
+		errB := errors.New("b b")
+
+		tests := map[string]struct {
+			err      error
+			expected string
+		}{
+			"nil": {
+				err:      nil,
+				expected: "error=nil",
+			},
+			"error": {
+				err:      errA,
+				expected: "error=a error.type=*errors.errorString",
+			},
+			"wrapped error": {
+				err:      fmt.Errorf("wrapped: %w", errA),
+				expected: `error="wrapped: a" error.type=*errors.errorString`,
+			},
+			"error wrapping multiple errors": {
+				err: fmt.Errorf("%w, %w", errA, errB),
+				expected: `error="a, b b" error.type=*fmt.wrapErrors error.0=a error.0.type=*errors.errorString ` +
+					`error.1="b b" error.1.type=*errors.errorString`,
+			},
+			"joined errors wrapped": {
+				err: fmt.Errorf("wrapped: %w", errors.Join(errA, errB)),
+				expected: "error=\"wrapped: a\nb b\" error.type=*errors.joinError error.0=a error.0.type=*errors.errorString " +
+					"error.1=\"b b\" error.1.type=*errors.errorString",
+			},
+		}
+
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				var builder strings.Builder
+				// when
+				logfmt.WriteError(&builder, "error", test.err)
+				// then
+				assert.Equal(t, test.expected, builder.String())
+			})
+		}
+	})
+}
@@ -6,12 +6,17 @@ package logrusadapter
 
 import (
 	"context"
+	"runtime"
+	"time"
 
 	"github.com/elgopher/yala/logger"
 	"github.com/sirupsen/logrus"
 )
 
 // Adapter is a logger.Adapter implementation, which is using `logrus` module (https://github.com/sirupsen/logrus).
+//
+// logrus reports the caller of its own logging method, which is the Adapter. Please add CallerHook to logrus logger
+// to report the caller of the entry instead.
 type Adapter struct {
 	Logger LogrusLogger
 }
@@ -24,40 +29,152 @@ type LogrusLogger interface {
 	Log(lvl logrus.Level, args ...interface{})
 }
 
-// Log logs the entry using logrus module.
+// Log logs the entry using logrus module. Error is logged with its concrete type and errors joined in it.
 func (a Adapter) Log(ctx context.Context, entry logger.Entry) {
 	if a.Logger == nil {
 		return
 	}
 
 	logrusLogger := loggerWithFields(a.Logger, entry)
-	logrusLogger.Log(logrusLevel(entry), entry.Message)
+	if !entry.Time.IsZero() {
+		logrusLogger = loggerWithTime(logrusLogger, entry.Time)
+	}
+
+	if frame, ok := entry.Caller(); ok {
+		logrusLogger = loggerWithCaller(ctx, logrusLogger, frame)
+	}
+
+	logrusLogger.Log(logrusLevel(entry.Level), entry.Message)
 }
 
-func loggerWithFields(logrusLogger LogrusLogger, entry logger.Entry) LogrusLogger { //nolint:ireturn
-	length := len(entry.Fields)
-	if entry.Error != nil {
-		length++
+// StackKey is a key of the field containing stack trace of the entry.
+const StackKey = "stack"
+
+// ReportsCaller returns true, because Entry.PC is used by CallerHook. See logger.CallerReporter.
+func (a Adapter) ReportsCaller() bool {
+	return a.Logger != nil
+}
+
+// CallerHook is a logrus.Hook which replaces the caller found by logrus with the caller of the entry logged by
+// Adapter (see logger.Entry.Caller). Please add it to logrus logger reporting the caller, for example:
+//
+//	logrusLogger.SetReportCaller(true)
+//	logrusLogger.AddHook(logrusadapter.CallerHook{})
+type CallerHook struct{}
+
+// Levels returns all levels.
+func (CallerHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire replaces the caller of logrus entry.
+func (CallerHook) Fire(logrusEntry *logrus.Entry) error {
+	if logrusEntry.Caller == nil || logrusEntry.Context == nil {
+		return nil
 	}
 
-	if length == 0 {
+	if frame, ok := logrusEntry.Context.Value(callerKey{}).(*runtime.Frame); ok {
+		logrusEntry.Caller = frame
+	}
+
+	return nil
+}
+
+// callerKey is a key of context value containing the caller of the entry. The context is passed to CallerHook.
+type callerKey struct{}
+
+// Enabled returns true if logrus logger is configured to log messages with given level.
+func (a Adapter) Enabled(_ context.Context, level logger.Level) bool {
+	switch logrusLogger := a.Logger.(type) {
+	case nil:
+		return false
+	case *logrus.Logger:
+		return logrusLogger.IsLevelEnabled(logrusLevel(level))
+	case *logrus.Entry:
+		return logrusLogger.Logger.IsLevelEnabled(logrusLevel(level))
+	default:
+		return true
+	}
+}
+
+func loggerWithFields(logrusLogger LogrusLogger, entry logger.Entry) LogrusLogger { //nolint:ireturn
+	if entry.Name == "" && len(entry.Fields) == 0 && entry.Error == nil && len(entry.Stack) == 0 {
 		return logrusLogger
 	}
 
 	fields := logrus.Fields{}
+
+	if entry.Name != "" {
+		fields[logger.NameKey] = entry.Name
+	}
+
+	prefix := "" // fields nested in groups have dotted keys, for example "group.key"
+
 	for _, field := range entry.Fields {
-		fields[field.Key] = field.Value
+		if field.Kind() == logger.KindGroup {
+			prefix += field.Key + "."
+
+			continue
+		}
+
+		fields[prefix+field.Key] = field.AnyValue()
 	}
 
 	if entry.Error != nil {
-		fields[logrus.ErrorKey] = entry.Error
+		addErrorFields(fields, entry.Error)
+	}
+
+	if len(entry.Stack) > 0 {
+		fields[StackKey] = entry.Stack.String()
 	}
 
 	return logrusLogger.WithFields(fields)
 }
 
-func logrusLevel(entry logger.Entry) logrus.Level {
-	switch entry.Level {
+// addErrorFields adds the error, the type of its root cause, and messages and types of errors joined in it. Keys
+// are prefixed with logrus.ErrorKey, for example "errorType", "errorCauses" and "errorCauseTypes".
+func addErrorFields(fields logrus.Fields, err error) {
+	fields[logrus.ErrorKey] = err
+	fields[logrus.ErrorKey+"Type"] = logger.ErrorType(err)
+
+	if causes := logger.ErrorCauses(err); len(causes) > 0 {
+		messages := make([]string, len(causes))
+		types := make([]string, len(causes))
+
+		for i, cause := range causes {
+			messages[i] = cause.Error()
+			types[i] = logger.ErrorType(cause)
+		}
+
+		fields[logrus.ErrorKey+"Causes"] = messages
+		fields[logrus.ErrorKey+"CauseTypes"] = types
+	}
+}
+
+func loggerWithTime(logrusLogger LogrusLogger, entryTime time.Time) LogrusLogger { //nolint:ireturn
+	if logrusEntry, ok := logrusLogger.(*logrus.Entry); ok {
+		return logrusEntry.WithTime(entryTime)
+	}
+
+	return logrusLogger.WithFields(nil).WithTime(entryTime)
+}
+
+func loggerWithCaller(ctx context.Context, logrusLogger LogrusLogger, frame runtime.Frame) LogrusLogger { //nolint:ireturn
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	ctx = context.WithValue(ctx, callerKey{}, &frame)
+
+	if logrusEntry, ok := logrusLogger.(*logrus.Entry); ok {
+		return logrusEntry.WithContext(ctx)
+	}
+
+	return logrusLogger.WithFields(nil).WithContext(ctx)
+}
+
+func logrusLevel(level logger.Level) logrus.Level {
+	switch level {
 	case logger.DebugLevel:
 		return logrus.DebugLevel
 	case logger.InfoLevel:
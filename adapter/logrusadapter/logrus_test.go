@@ -6,7 +6,11 @@ package logrusadapter_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
+	"runtime"
+	"strings"
 	"testing"
 	"time"
 
@@ -33,12 +37,170 @@ func TestAdapter_Log(t *testing.T) {
 		})
 	})
 
+	t.Run("should log fields nested in groups with dotted keys", func(t *testing.T) {
+		var builder strings.Builder
+		adapter := newAdapter(&builder)
+		// when
+		adapter.Log(ctx, logger.Entry{
+			Level:   logger.InfoLevel,
+			Message: message,
+			Fields: []logger.Field{
+				logger.String("k1", "v1"),
+				logger.Group("http"),
+				logger.Int("status", 200),
+				logger.Group("db"),
+				logger.String("k2", "v2"),
+			},
+		})
+		// then
+		var out map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(builder.String()), &out))
+		assert.Equal(t, "v1", out["k1"])
+		assert.Equal(t, 200.0, out["http.status"])
+		assert.Equal(t, "v2", out["http.db.k2"])
+	})
+
+	t.Run("should log joined errors with type and causes with their types", func(t *testing.T) {
+		var builder strings.Builder
+		adapter := newAdapter(&builder)
+		// when
+		adapter.Log(ctx, logger.Entry{
+			Level:   logger.ErrorLevel,
+			Message: message,
+			Error:   errors.Join(errors.New("a"), errors.New("b")),
+		})
+		// then
+		var out map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(builder.String()), &out))
+		assert.Equal(t, "a\nb", out["error"])
+		assert.Equal(t, "*errors.joinError", out["errorType"])
+		assert.Equal(t, []interface{}{"a", "b"}, out["errorCauses"])
+		assert.Equal(t, []interface{}{"*errors.errorString", "*errors.errorString"}, out["errorCauseTypes"])
+	})
+
+	t.Run("should log entry name", func(t *testing.T) {
+		var builder strings.Builder
+		adapter := newAdapter(&builder)
+		// when
+		adapter.Log(ctx, logger.Entry{
+			Level:   logger.InfoLevel,
+			Message: message,
+			Name:    "payments.db",
+		})
+		// then
+		var out map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(builder.String()), &out))
+		assert.Equal(t, "payments.db", out[logger.NameKey])
+	})
+
+	t.Run("should log entry stack trace", func(t *testing.T) {
+		var builder strings.Builder
+		adapter := newAdapter(&builder)
+		var pcs [2]uintptr
+		runtime.Callers(1, pcs[:])
+		stack := logger.Stack(pcs[:])
+		// when
+		adapter.Log(ctx, logger.Entry{
+			Level:   logger.InfoLevel,
+			Message: message,
+			Stack:   stack,
+		})
+		// then
+		out := unmarshalLogrusMessage(t, builder.String())
+		assert.Equal(t, stack.String(), out.Stack)
+	})
+
+	t.Run("should log entry time", func(t *testing.T) {
+		entryTime := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+
+		loggers := map[string]func(io.Writer) logger.Adapter{
+			"logrus.Logger": newAdapter,
+			"logrus.Entry": func(writer io.Writer) logger.Adapter {
+				adapter := newAdapter(writer).(logrusadapter.Adapter) //nolint:forcetypeassert
+				adapter.Logger = adapter.Logger.WithField("k", "v")
+
+				return adapter
+			},
+		}
+
+		for name, newLoggerAdapter := range loggers {
+			t.Run(name, func(t *testing.T) {
+				var builder strings.Builder
+				adapter := newLoggerAdapter(&builder)
+				// when
+				adapter.Log(ctx, logger.Entry{
+					Level:   logger.InfoLevel,
+					Message: message,
+					Time:    entryTime,
+				})
+				// then
+				out := unmarshalLogrusMessage(t, builder.String())
+				assert.True(t, entryTime.Equal(out.Time), "expected %s, got %s", entryTime, out.Time)
+			})
+		}
+	})
+
+	t.Run("should log caller of entry using CallerHook", func(t *testing.T) {
+		var builder strings.Builder
+		logrusLogger := logrus.New()
+		logrusLogger.SetFormatter(&logrus.JSONFormatter{})
+		logrusLogger.SetOutput(&builder)
+		logrusLogger.SetReportCaller(true)
+		logrusLogger.AddHook(logrusadapter.CallerHook{})
+		log := logger.WithAdapter(logrusadapter.Adapter{Logger: logrusLogger.WithField("k", "v")})
+		// when
+		log.Info(ctx, message)
+		_, file, line, _ := runtime.Caller(0)
+		// then
+		out := unmarshalLogrusMessage(t, builder.String())
+		assert.Equal(t, fmt.Sprintf("%s:%d", file, line-1), out.File)
+	})
+
 	adaptertest.Run(t, adaptertest.Subject{
 		NewAdapter:       newAdapter,
 		UnmarshalMessage: unmarshalMessage,
 	})
 }
 
+func TestAdapter_Enabled(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should return false when logger is nil", func(t *testing.T) {
+		adapter := logrusadapter.Adapter{Logger: nil}
+		assert.False(t, adapter.Enabled(ctx, logger.ErrorLevel))
+	})
+
+	logrusLogger := logrus.New()
+	logrusLogger.SetLevel(logrus.WarnLevel)
+
+	loggers := map[string]logrusadapter.LogrusLogger{
+		"logrus.Logger": logrusLogger,
+		"logrus.Entry":  logrusLogger.WithField("k", "v"),
+	}
+
+	for name, logrusLogger := range loggers {
+		t.Run("should use logrus logger level for "+name, func(t *testing.T) {
+			adapter := logrusadapter.Adapter{Logger: logrusLogger}
+			assert.False(t, adapter.Enabled(ctx, logger.DebugLevel))
+			assert.False(t, adapter.Enabled(ctx, logger.InfoLevel))
+			assert.True(t, adapter.Enabled(ctx, logger.WarnLevel))
+			assert.True(t, adapter.Enabled(ctx, logger.ErrorLevel))
+		})
+	}
+}
+
+func TestAdapter_ReportsCaller(t *testing.T) {
+	t.Run("should return false when logger is nil", func(t *testing.T) {
+		adapter := logrusadapter.Adapter{Logger: nil}
+		assert.False(t, adapter.ReportsCaller())
+	})
+
+	t.Run("should return true", func(t *testing.T) {
+		adapter := logrusadapter.Adapter{Logger: logrus.New()}
+		assert.True(t, adapter.ReportsCaller())
+	})
+}
+
 func newAdapter(writer io.Writer) logger.Adapter {
 	logrusLogger := logrus.New()
 	logrusLogger.SetFormatter(&logrus.JSONFormatter{})
@@ -58,9 +220,7 @@ var levelMapping = map[string]logger.Level{
 func unmarshalMessage(t *testing.T, line string) adaptertest.Message {
 	t.Helper()
 
-	out := logrusMessage{}
-	err := json.Unmarshal([]byte(line), &out)
-	require.NoError(t, err)
+	out := unmarshalLogrusMessage(t, line)
 
 	return adaptertest.Message{
 		Level:          levelMapping[out.Level],
@@ -70,21 +230,38 @@ func unmarshalMessage(t *testing.T, line string) adaptertest.Message {
 		IntField:       out.IntField,
 		Int64Field:     out.Int64Field,
 		Float32Field:   out.Float32Field,
+		Uint64Field:    out.Uint64Field,
 		Float64Field:   out.Float64Field,
+		BoolField:      out.BoolField,
 		TimeField:      out.TimeField,
 		InterfaceField: out.InterfaceField,
 	}
 }
 
+func unmarshalLogrusMessage(t *testing.T, line string) logrusMessage {
+	t.Helper()
+
+	out := logrusMessage{}
+	err := json.Unmarshal([]byte(line), &out)
+	require.NoError(t, err)
+
+	return out
+}
+
 type logrusMessage struct {
 	Level          string
 	Msg            string
+	Time           time.Time
+	Stack          string
+	File           string // caller
 	Error          string
 	StringField    string
 	IntField       int
 	Int64Field     int64
 	Float32Field   float32
+	Uint64Field    uint64
 	Float64Field   float64
+	BoolField      bool
 	TimeField      time.Time
 	InterfaceField adaptertest.InterfaceField
 }
@@ -6,6 +6,7 @@ package printer
 import (
 	"context"
 	"strings"
+	"time"
 
 	"github.com/elgopher/yala/adapter/logfmt"
 	"github.com/elgopher/yala/logger"
@@ -13,12 +14,18 @@ import (
 
 // Adapter is a logger.Adapter implementation, which is using Printer interface.
 //
-// This adapter prints fields and error in logfmt format. For example:
+// This adapter prints logger name, fields and error in logfmt format. For example:
 //
-// 		message key=value error=message
+// 		message logger=name key=value error=message error.type=*errors.errorString
 //
+// Stack trace of the entry (if captured) is printed in the following lines, indented with a tab.
 type Adapter struct {
 	Printer Printer
+	// TimeFormat is a layout used to format the time of the entry (see time.Layout). When not empty, the time is
+	// printed at the beginning of the line. Please note that the time is not printed by default, because
+	// most printers print the time themselves. Printers implementing EntryPrinter can print the entry time
+	// instead (for example, the one used by logadapter).
+	TimeFormat string
 }
 
 // Printer is someone who can print lines.
@@ -27,6 +34,17 @@ type Printer interface {
 	Println(skipCallerFrames int, msg string)
 }
 
+// EntryPrinter is an optional interface which can be implemented by Printer to print information about the entry,
+// such as its caller (see logger.Entry.Caller). When implemented, PrintlnEntry is used instead of Println.
+//
+// Printer can also implement logger.CallerReporter to ask the logger for capturing the caller (see Adapter
+// ReportsCaller).
+type EntryPrinter interface {
+	// PrintlnEntry prints line with information about the entry. Entry SkippedCallerFrames can be used to find
+	// the caller, when Entry.Caller returns false.
+	PrintlnEntry(entry logger.Entry, msg string)
+}
+
 // Log logs the entry using Printer. Message is formatted using logfmt.
 func (f Adapter) Log(ctx context.Context, entry logger.Entry) {
 	if f.Printer == nil {
@@ -35,19 +53,61 @@ func (f Adapter) Log(ctx context.Context, entry logger.Entry) {
 
 	var builder strings.Builder
 
+	if f.TimeFormat != "" {
+		entryTime := entry.Time
+		if entryTime.IsZero() {
+			entryTime = time.Now()
+		}
+
+		builder.WriteString(entryTime.Format(f.TimeFormat))
+		builder.WriteByte(' ')
+	}
+
 	builder.WriteString(entry.Level.String())
 	builder.WriteByte(' ')
 	builder.WriteString(entry.Message)
 
-	if len(entry.Fields) > 0 {
+	if entry.Name != "" {
+		builder.WriteByte(' ')
+		logfmt.WriteField(&builder, logger.String(logger.NameKey, entry.Name))
+	}
+
+	if logfmt.HasFieldsWithValue(entry.Fields) {
 		builder.WriteByte(' ')
 		logfmt.WriteFields(&builder, entry.Fields)
 	}
 
 	if entry.Error != nil {
 		builder.WriteByte(' ')
-		logfmt.WriteField(&builder, logger.Field{Key: "error", Value: entry.Error})
+		logfmt.WriteError(&builder, "error", entry.Error)
+	}
+
+	if len(entry.Stack) > 0 {
+		writeStack(&builder, entry.Stack)
+	}
+
+	if entryPrinter, ok := f.Printer.(EntryPrinter); ok {
+		entry.SkippedCallerFrames++
+		entryPrinter.PrintlnEntry(entry, builder.String())
+
+		return
 	}
 
 	f.Printer.Println(entry.SkippedCallerFrames+1, builder.String())
 }
+
+// ReportsCaller returns true if Printer implements logger.CallerReporter and reports that it uses Entry.PC.
+// See logger.CallerReporter.
+func (f Adapter) ReportsCaller() bool {
+	reporter, ok := f.Printer.(logger.CallerReporter)
+
+	return ok && reporter.ReportsCaller()
+}
+
+// writeStack writes stack trace in new lines, indented with a tab.
+func writeStack(builder *strings.Builder, stack logger.Stack) {
+	for _, line := range strings.Split(stack.String(), "\n") {
+		builder.WriteString("\n\t")
+		builder.WriteString(line)
+	}
+}
@@ -5,14 +5,18 @@ package printer_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"runtime"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/elgopher/yala/adapter/printer"
 	"github.com/elgopher/yala/logger"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var ctx = context.Background()
@@ -46,13 +50,30 @@ func TestAdapter_Log(t *testing.T) {
 			},
 			expectedMessage: "INFO message k=v\n",
 		},
+		"name": {
+			entry: logger.Entry{
+				Level:   logger.InfoLevel,
+				Message: message,
+				Name:    "payments.db",
+				Fields:  []logger.Field{{Key: "k", Value: "v"}},
+			},
+			expectedMessage: "INFO message logger=payments.db k=v\n",
+		},
+		"secret": {
+			entry: logger.Entry{
+				Level:   logger.InfoLevel,
+				Message: message,
+				Fields:  []logger.Field{{Key: "password", Value: logger.NewSecret("pass")}},
+			},
+			expectedMessage: "INFO message password=[REDACTED]\n",
+		},
 		"error": {
 			entry: logger.Entry{
 				Level:   logger.ErrorLevel,
 				Message: message,
 				Error:   stringError("err message"),
 			},
-			expectedMessage: "ERROR message error=\"err message\"\n",
+			expectedMessage: "ERROR message error=\"err message\" error.type=printer_test.stringError\n",
 		},
 		"fields and error": {
 			entry: logger.Entry{
@@ -61,7 +82,32 @@ func TestAdapter_Log(t *testing.T) {
 				Fields:  []logger.Field{{Key: "k", Value: "v"}},
 				Error:   stringError("err message"),
 			},
-			expectedMessage: "ERROR message k=v error=\"err message\"\n",
+			expectedMessage: "ERROR message k=v error=\"err message\" error.type=printer_test.stringError\n",
+		},
+		"joined errors": {
+			entry: logger.Entry{
+				Level:   logger.ErrorLevel,
+				Message: message,
+				Error:   errors.Join(stringError("a"), stringError("b")),
+			},
+			expectedMessage: "ERROR message error=a\nb error.type=*errors.joinError " +
+				"error.0=a error.0.type=printer_test.stringError error.1=b error.1.type=printer_test.stringError\n",
+		},
+		"group": {
+			entry: logger.Entry{
+				Level:   logger.InfoLevel,
+				Message: message,
+				Fields:  []logger.Field{logger.Group("g"), {Key: "k", Value: "v"}},
+			},
+			expectedMessage: "INFO message g.k=v\n",
+		},
+		"group without fields": {
+			entry: logger.Entry{
+				Level:   logger.InfoLevel,
+				Message: message,
+				Fields:  []logger.Field{logger.Group("g")},
+			},
+			expectedMessage: "INFO message\n",
 		},
 	}
 
@@ -76,6 +122,69 @@ func TestAdapter_Log(t *testing.T) {
 		})
 	}
 
+	t.Run("should print stack trace as indented block", func(t *testing.T) {
+		var actual strings.Builder
+		adapter := printer.Adapter{Printer: stringPrinter{&actual}}
+		var pcs [2]uintptr
+		runtime.Callers(1, pcs[:])
+		stack := logger.Stack(pcs[:])
+		// when
+		adapter.Log(ctx, logger.Entry{
+			Level:   logger.ErrorLevel,
+			Message: message,
+			Stack:   stack,
+		})
+		// then
+		expected := "ERROR message\n\t" + strings.ReplaceAll(stack.String(), "\n", "\n\t") + "\n"
+		assert.Equal(t, expected, actual.String())
+	})
+
+	t.Run("should print entry time using TimeFormat", func(t *testing.T) {
+		var actual strings.Builder
+		adapter := printer.Adapter{Printer: stringPrinter{&actual}, TimeFormat: time.RFC3339}
+		// when
+		adapter.Log(ctx, logger.Entry{
+			Level:   logger.InfoLevel,
+			Message: message,
+			Time:    time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC),
+		})
+		// then
+		assert.Equal(t, "2022-01-02T03:04:05Z INFO message\n", actual.String())
+	})
+
+	t.Run("should print current time when entry time is zero", func(t *testing.T) {
+		var actual strings.Builder
+		adapter := printer.Adapter{Printer: stringPrinter{&actual}, TimeFormat: time.RFC3339}
+		// when
+		adapter.Log(ctx, logger.Entry{
+			Level:   logger.InfoLevel,
+			Message: message,
+		})
+		// then
+		timeString, _, found := strings.Cut(actual.String(), " ")
+		require.True(t, found)
+		_, err := time.Parse(time.RFC3339, timeString)
+		assert.NoError(t, err)
+	})
+
+	t.Run("should pass entry to EntryPrinter", func(t *testing.T) {
+		entryPrinter := &entryPrinterMock{}
+		adapter := printer.Adapter{Printer: entryPrinter}
+		entry := logger.Entry{
+			Level:               logger.InfoLevel,
+			Message:             message,
+			PC:                  1234,
+			SkippedCallerFrames: 1,
+		}
+		// when
+		adapter.Log(ctx, entry)
+		// then
+		require.Len(t, entryPrinter.entries, 1)
+		assert.Equal(t, uintptr(1234), entryPrinter.entries[0].PC)
+		assert.Equal(t, 2, entryPrinter.entries[0].SkippedCallerFrames)
+		assert.Equal(t, []string{"INFO message"}, entryPrinter.messages)
+	})
+
 	t.Run("should not panic when printer is nil", func(t *testing.T) {
 		adapter := printer.Adapter{Printer: nil}
 		assert.NotPanics(t, func() {
@@ -87,6 +196,20 @@ func TestAdapter_Log(t *testing.T) {
 	})
 }
 
+func TestAdapter_ReportsCaller(t *testing.T) {
+	t.Run("should return false when printer does not implement logger.CallerReporter", func(t *testing.T) {
+		adapter := printer.Adapter{Printer: stringPrinter{}}
+		assert.False(t, adapter.ReportsCaller())
+	})
+
+	t.Run("should pass the check to printer", func(t *testing.T) {
+		for _, reportsCaller := range []bool{true, false} {
+			adapter := printer.Adapter{Printer: &entryPrinterMock{reportsCaller: reportsCaller}}
+			assert.Equal(t, reportsCaller, adapter.ReportsCaller())
+		}
+	})
+}
+
 type stringError string
 
 func (e stringError) Error() string {
@@ -101,3 +224,22 @@ func (p stringPrinter) Println(_ int, msg string) {
 	s := fmt.Sprintln(msg)
 	_, _ = p.WriteString(s)
 }
+
+type entryPrinterMock struct {
+	reportsCaller bool
+	entries       []logger.Entry
+	messages      []string
+}
+
+func (p *entryPrinterMock) Println(int, string) {
+	panic("PrintlnEntry should be used instead")
+}
+
+func (p *entryPrinterMock) PrintlnEntry(entry logger.Entry, msg string) {
+	p.entries = append(p.entries, entry)
+	p.messages = append(p.messages, msg)
+}
+
+func (p *entryPrinterMock) ReportsCaller() bool {
+	return p.reportsCaller
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/elgopher/yala/adapter/console"
+	"github.com/elgopher/yala/adapter/ratelimit"
+	"github.com/elgopher/yala/logger"
+)
+
+var ErrSome = errors.New("ErrSome")
+
+// This example shows how to limit the rate of error messages
+func main() {
+	ctx := context.Background()
+
+	// create middleware adapter which passes at most 10 error messages per second for each component:
+	adapter := &ratelimit.Adapter{
+		NextAdapter: console.StdoutAdapter(),
+		Limits: map[logger.Level]ratelimit.Limit{
+			logger.ErrorLevel: {Rate: 10, Burst: 10},
+		},
+		KeyField: "component",
+	}
+
+	log := logger.WithAdapter(adapter).With("component", "db")
+
+	for i := 0; i < 1000; i++ {
+		log.ErrorCause(ctx, "Query failed", ErrSome)
+	}
+
+	// summary entry "suppressed 990 entries" is logged once the bucket is refilled (after 100ms):
+	time.Sleep(time.Second)
+
+	// pending summaries are passed on Sync too:
+	_ = logger.Sync(adapter)
+}
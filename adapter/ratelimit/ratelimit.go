@@ -0,0 +1,356 @@
+// (c) 2022 Jacek Olszak
+// This code is licensed under MIT license (see LICENSE for details)
+
+// Package ratelimit provides middleware adapter limiting the rate of logged entries using token buckets.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/elgopher/yala/logger"
+)
+
+// SuppressedKey is a key of the field containing the number of suppressed entries in summary entry.
+const SuppressedKey = "suppressed"
+
+// Adapter is a middleware (decorator) adapter which limits the rate of entries passed to NextAdapter. Each level
+// has its own token bucket configured in Limits. Entries exceeding the limit are suppressed.
+//
+// Once the limit lifts, that is when the bucket is refilled or the next entry is allowed (whichever comes first),
+// a summary entry with the same level, name and context (without cancellation) as the last suppressed entry
+// is passed. For example:
+//
+//	ERROR suppressed 1234 entries suppressed=1234
+//
+// The time of the entry (or the current time, if the entry has no time) is used to refill buckets. Please use Flush
+// to pass all pending summaries, for example before application exits.
+//
+// Adapter must not be copied after first use.
+type Adapter struct {
+	NextAdapter logger.Adapter
+	// Limits defines a token bucket for each level. Entries with level not present in the map are not limited.
+	Limits map[logger.Level]Limit
+	// KeyField is an optional key of the field, for example "component". When not empty, a separate token bucket is
+	// used for each value of this field. The field is also added to the summary entry. Please note that the number of
+	// buckets grows with the number of distinct values, therefore the field should have a low cardinality.
+	KeyField string
+	// Now returns the current time. When nil, time.Now is used.
+	Now func() time.Time
+	// AfterFunc runs f in its own goroutine after duration d. It returns a function stopping the timer. When nil,
+	// time.AfterFunc is used.
+	AfterFunc func(d time.Duration, f func()) (stop func() bool)
+
+	mutex   sync.Mutex
+	buckets map[bucketKey]*bucket
+}
+
+// Limit configures a token bucket.
+type Limit struct {
+	// Rate is the number of entries allowed per second.
+	Rate float64
+	// Burst is the maximum number of entries allowed at once. Values less than 1 are treated as 1.
+	Burst int
+}
+
+type bucketKey struct {
+	level logger.Level
+	key   string
+}
+
+type summary struct {
+	ctx         context.Context //nolint:containedctx // context of the last suppressed entry is passed to NextAdapter
+	name        string
+	level       logger.Level
+	time        time.Time
+	keyField    logger.Field
+	hasKeyField bool
+	suppressed  uint64
+}
+
+// Log passes the entry to the next adapter or suppresses it.
+func (a *Adapter) Log(ctx context.Context, entry logger.Entry) {
+	if a.NextAdapter == nil {
+		return
+	}
+
+	entry.SkippedCallerFrames++ // each middleware adapter must additionally skip one frame
+
+	limit, ok := a.Limits[entry.Level]
+	if !ok {
+		a.NextAdapter.Log(ctx, entry)
+
+		return
+	}
+
+	entryTime := entry.Time
+	if entryTime.IsZero() {
+		entryTime = a.now()
+	}
+
+	keyField, hasKeyField := a.keyField(entry)
+	key := bucketKey{level: entry.Level, key: keyValue(keyField)}
+
+	allowed, pending := a.take(ctx, entry.Name, key, limit, entryTime, keyField, hasKeyField)
+	if !allowed {
+		return
+	}
+
+	if pending != nil {
+		a.logSummary(*pending, entry.SkippedCallerFrames)
+	}
+
+	a.NextAdapter.Log(ctx, entry)
+}
+
+// take takes a token from the bucket. When the token is taken, it returns the pending summary of entries suppressed
+// since the last allowed entry (if any). keyField is added to summaries of a newly created bucket. ctx and name
+// of the suppressed entry are remembered for the summary.
+func (a *Adapter) take(ctx context.Context, name string, key bucketKey, limit Limit, now time.Time,
+	keyField logger.Field, hasKeyField bool,
+) (allowed bool, pending *summary) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.buckets == nil {
+		a.buckets = map[bucketKey]*bucket{}
+	}
+
+	b, ok := a.buckets[key]
+	if !ok {
+		b = newBucket(limit, now)
+		b.keyField, b.hasKeyField = keyField, hasKeyField
+		a.buckets[key] = b
+	}
+
+	if !b.take(limit, now) {
+		b.suppressed++
+		b.lastName = name
+		b.lastCtx = ctx
+
+		if ctx != nil {
+			b.lastCtx = context.WithoutCancel(ctx) // the summary can be passed after the request is finished
+		}
+
+		if b.stopTimer == nil && limit.Rate > 0 {
+			b.stopTimer = a.afterFunc(b.untilRefilled(limit), func() {
+				a.summaryTimerFired(key)
+			})
+		}
+
+		return false, nil
+	}
+
+	return true, b.pendingSummary(key.level, now)
+}
+
+// summaryTimerFired passes the summary of suppressed entries once the bucket is refilled.
+func (a *Adapter) summaryTimerFired(key bucketKey) {
+	a.mutex.Lock()
+
+	var pending *summary
+	if b, ok := a.buckets[key]; ok {
+		b.stopTimer = nil
+		pending = b.pendingSummary(key.level, a.now())
+	}
+
+	a.mutex.Unlock()
+
+	if pending != nil {
+		a.logSummary(*pending, 1) // summaryTimerFired
+	}
+}
+
+// logSummary passes the summary entry. skippedCallerFrames is the number of frames skipped by the caller
+// of logSummary.
+func (a *Adapter) logSummary(s summary, skippedCallerFrames int) {
+	entry := logger.Entry{
+		Level:   s.level,
+		Message: fmt.Sprintf("suppressed %d entries", s.suppressed),
+		Name:    s.name,
+		Time:    s.time,
+		// the entry is passed from a different place than suppressed entries were logged
+		SkippedCallerFrames: skippedCallerFrames + 1, // logSummary
+	}
+
+	if s.hasKeyField {
+		entry.Fields = append(entry.Fields, s.keyField)
+	}
+
+	entry.Fields = append(entry.Fields, logger.Uint64(SuppressedKey, s.suppressed))
+
+	a.NextAdapter.Log(s.ctx, entry)
+}
+
+// Flush passes summaries of all suppressed entries to the next adapter.
+func (a *Adapter) Flush() {
+	if a.NextAdapter == nil {
+		return
+	}
+
+	a.mutex.Lock()
+
+	now := a.now()
+
+	var pending []summary
+
+	for key, b := range a.buckets {
+		if s := b.pendingSummary(key.level, now); s != nil {
+			pending = append(pending, *s)
+		}
+	}
+
+	a.mutex.Unlock()
+
+	for _, s := range pending {
+		a.logSummary(s, 1) // Flush
+	}
+}
+
+// keyField returns the first field (not nested in a group) with KeyField key.
+func (a *Adapter) keyField(entry logger.Entry) (logger.Field, bool) {
+	if a.KeyField == "" {
+		return logger.Field{}, false
+	}
+
+	for _, field := range entry.Fields {
+		if field.Kind() == logger.KindGroup {
+			break
+		}
+
+		if field.Key == a.KeyField {
+			return field, true
+		}
+	}
+
+	return logger.Field{}, false
+}
+
+func keyValue(field logger.Field) string {
+	if field.Kind() == logger.KindString {
+		return field.StringValue()
+	}
+
+	return fmt.Sprint(field.AnyValue())
+}
+
+// Enabled passes the check to the next adapter. See logger.LevelEnabler.
+func (a *Adapter) Enabled(ctx context.Context, level logger.Level) bool {
+	return a.NextAdapter != nil && logger.Enabled(ctx, a.NextAdapter, level)
+}
+
+// ReportsCaller passes the check to the next adapter. See logger.CallerReporter.
+func (a *Adapter) ReportsCaller() bool {
+	return logger.ReportsCaller(a.NextAdapter)
+}
+
+// Sync passes pending summaries (see Flush) and then passes the call to the next adapter. See logger.Syncer.
+func (a *Adapter) Sync() error {
+	a.Flush()
+
+	return logger.Sync(a.NextAdapter)
+}
+
+// Close passes pending summaries (see Flush) and then passes the call to the next adapter. See logger.Closer.
+func (a *Adapter) Close() error {
+	a.Flush()
+
+	return logger.Close(a.NextAdapter)
+}
+
+func (a *Adapter) now() time.Time {
+	if a.Now == nil {
+		return time.Now()
+	}
+
+	return a.Now()
+}
+
+func (a *Adapter) afterFunc(d time.Duration, f func()) (stop func() bool) {
+	if a.AfterFunc == nil {
+		return time.AfterFunc(d, f).Stop
+	}
+
+	return a.AfterFunc(d, f)
+}
+
+type bucket struct {
+	tokens      float64
+	lastRefill  time.Time
+	keyField    logger.Field
+	hasKeyField bool
+	suppressed  uint64
+	lastCtx     context.Context //nolint:containedctx // context of the last suppressed entry is passed to NextAdapter
+	lastName    string          // name of the last suppressed entry
+	stopTimer   func() bool     // not nil, when the summary timer is scheduled
+}
+
+func newBucket(limit Limit, now time.Time) *bucket {
+	return &bucket{
+		tokens:     burst(limit),
+		lastRefill: now,
+	}
+}
+
+func (b *bucket) take(limit Limit, now time.Time) bool {
+	if elapsed := now.Sub(b.lastRefill); elapsed > 0 {
+		b.tokens += elapsed.Seconds() * limit.Rate
+		if maxTokens := burst(limit); b.tokens > maxTokens {
+			b.tokens = maxTokens
+		}
+
+		b.lastRefill = now
+	}
+
+	if b.tokens < 1 {
+		return false
+	}
+
+	b.tokens--
+
+	return true
+}
+
+// untilRefilled returns the time after which the bucket will have at least one token.
+func (b *bucket) untilRefilled(limit Limit) time.Duration {
+	return time.Duration((1 - b.tokens) / limit.Rate * float64(time.Second))
+}
+
+// pendingSummary returns the summary of suppressed entries and resets the counter. It returns nil, when no entries
+// were suppressed.
+func (b *bucket) pendingSummary(level logger.Level, now time.Time) *summary {
+	if b.suppressed == 0 {
+		return nil
+	}
+
+	if b.stopTimer != nil {
+		b.stopTimer()
+		b.stopTimer = nil
+	}
+
+	s := &summary{
+		ctx:         b.lastCtx,
+		name:        b.lastName,
+		level:       level,
+		time:        now,
+		keyField:    b.keyField,
+		hasKeyField: b.hasKeyField,
+		suppressed:  b.suppressed,
+	}
+
+	b.suppressed = 0
+	b.lastCtx = nil
+	b.lastName = ""
+
+	return s
+}
+
+func burst(limit Limit) float64 {
+	if limit.Burst < 1 {
+		return 1
+	}
+
+	return float64(limit.Burst)
+}
@@ -0,0 +1,339 @@
+// (c) 2022 Jacek Olszak
+// This code is licensed under MIT license (see LICENSE for details)
+
+package ratelimit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/elgopher/yala/adapter/internal/adaptertest"
+	"github.com/elgopher/yala/adapter/internal/fake"
+	"github.com/elgopher/yala/adapter/ratelimit"
+	"github.com/elgopher/yala/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const message = "message"
+
+var ctx = context.Background()
+
+var entryTime = time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func TestAdapter_Log(t *testing.T) {
+	t.Run("should not panic when next adapter is nil", func(t *testing.T) {
+		adapter := &ratelimit.Adapter{}
+		assert.NotPanics(t, func() {
+			adapter.Log(ctx, logger.Entry{Level: logger.InfoLevel, Message: message})
+		})
+	})
+
+	t.Run("should not limit entries with level without limit", func(t *testing.T) {
+		next := &fake.Adapter{}
+		adapter := &ratelimit.Adapter{
+			NextAdapter: next,
+			Limits:      map[logger.Level]ratelimit.Limit{logger.ErrorLevel: {Rate: 1}},
+			AfterFunc:   fake.NewClock(entryTime).AfterFunc,
+		}
+		// when
+		for i := 0; i < 3; i++ {
+			adapter.Log(ctx, entry(logger.InfoLevel, 0))
+		}
+		// then
+		assert.Len(t, next.Entries(), 3)
+	})
+
+	t.Run("should suppress entries exceeding burst", func(t *testing.T) {
+		next := &fake.Adapter{}
+		adapter := &ratelimit.Adapter{
+			NextAdapter: next,
+			Limits:      map[logger.Level]ratelimit.Limit{logger.ErrorLevel: {Rate: 1, Burst: 2}},
+			AfterFunc:   fake.NewClock(entryTime).AfterFunc,
+		}
+		// when
+		for i := 0; i < 5; i++ {
+			adapter.Log(ctx, entry(logger.ErrorLevel, 0))
+		}
+		// then
+		assert.Len(t, next.Entries(), 2)
+	})
+
+	t.Run("should treat burst less than 1 as 1", func(t *testing.T) {
+		next := &fake.Adapter{}
+		adapter := &ratelimit.Adapter{
+			NextAdapter: next,
+			Limits:      map[logger.Level]ratelimit.Limit{logger.ErrorLevel: {Rate: 1}},
+			AfterFunc:   fake.NewClock(entryTime).AfterFunc,
+		}
+		// when
+		adapter.Log(ctx, entry(logger.ErrorLevel, 0))
+		adapter.Log(ctx, entry(logger.ErrorLevel, 0))
+		// then
+		assert.Len(t, next.Entries(), 1)
+	})
+
+	t.Run("should pass summary entry once the limit lifts", func(t *testing.T) {
+		next := &fake.Adapter{}
+		adapter := &ratelimit.Adapter{
+			NextAdapter: next,
+			Limits:      map[logger.Level]ratelimit.Limit{logger.ErrorLevel: {Rate: 2, Burst: 1}},
+			AfterFunc:   fake.NewClock(entryTime).AfterFunc,
+		}
+		adapter.Log(ctx, entry(logger.ErrorLevel, 0))
+		adapter.Log(ctx, entry(logger.ErrorLevel, 100*time.Millisecond))
+		adapter.Log(ctx, entry(logger.ErrorLevel, 200*time.Millisecond))
+		// when
+		adapter.Log(ctx, entry(logger.ErrorLevel, 500*time.Millisecond))
+		// then
+		entries := next.Entries()
+		require.Len(t, entries, 3)
+		assert.Equal(t, message, entries[0].Message)
+		summary := entries[1]
+		assert.Equal(t, logger.ErrorLevel, summary.Level)
+		assert.Equal(t, "suppressed 2 entries", summary.Message)
+		assert.Equal(t, []logger.Field{logger.Uint64(ratelimit.SuppressedKey, 2)}, summary.Fields)
+		assert.Equal(t, entryTime.Add(500*time.Millisecond), summary.Time)
+		assert.Equal(t, message, entries[2].Message)
+	})
+
+	t.Run("should pass summary entry when the bucket is refilled", func(t *testing.T) {
+		next := &fake.Adapter{}
+		clock := fake.NewClock(entryTime)
+		adapter := &ratelimit.Adapter{
+			NextAdapter: next,
+			Limits:      map[logger.Level]ratelimit.Limit{logger.ErrorLevel: {Rate: 2, Burst: 1}},
+			Now:         clock.Now,
+			AfterFunc:   clock.AfterFunc,
+		}
+		adapter.Log(ctx, logger.Entry{Level: logger.ErrorLevel, Message: message})
+		adapter.Log(ctx, logger.Entry{Level: logger.ErrorLevel, Message: message})
+		adapter.Log(ctx, logger.Entry{Level: logger.ErrorLevel, Message: message})
+		// when
+		clock.Advance(500 * time.Millisecond)
+		// then
+		entries := next.Entries()
+		require.Len(t, entries, 2)
+		summary := entries[1]
+		assert.Equal(t, logger.ErrorLevel, summary.Level)
+		assert.Equal(t, "suppressed 2 entries", summary.Message)
+		assert.Equal(t, []logger.Field{logger.Uint64(ratelimit.SuppressedKey, 2)}, summary.Fields)
+		assert.Equal(t, entryTime.Add(500*time.Millisecond), summary.Time)
+	})
+
+	t.Run("should not pass summary entry twice", func(t *testing.T) {
+		next := &fake.Adapter{}
+		clock := fake.NewClock(entryTime)
+		adapter := &ratelimit.Adapter{
+			NextAdapter: next,
+			Limits:      map[logger.Level]ratelimit.Limit{logger.ErrorLevel: {Rate: 2, Burst: 1}},
+			AfterFunc:   clock.AfterFunc,
+		}
+		adapter.Log(ctx, entry(logger.ErrorLevel, 0))
+		adapter.Log(ctx, entry(logger.ErrorLevel, 100*time.Millisecond))
+		adapter.Log(ctx, entry(logger.ErrorLevel, 500*time.Millisecond))
+		// when
+		clock.Advance(time.Second)
+		// then
+		assert.Len(t, next.Entries(), 3)
+		assert.Zero(t, clock.Timers())
+	})
+
+	t.Run("should not copy PC of allowed entry into summary entry", func(t *testing.T) {
+		next := &fake.Adapter{}
+		adapter := &ratelimit.Adapter{
+			NextAdapter: next,
+			Limits:      map[logger.Level]ratelimit.Limit{logger.ErrorLevel: {Rate: 1}},
+			AfterFunc:   fake.NewClock(entryTime).AfterFunc,
+		}
+		adapter.Log(ctx, entry(logger.ErrorLevel, 0))
+		adapter.Log(ctx, entry(logger.ErrorLevel, 0))
+		allowedEntry := entry(logger.ErrorLevel, time.Second)
+		allowedEntry.PC = 1234
+		// when
+		adapter.Log(ctx, allowedEntry)
+		// then
+		entries := next.Entries()
+		require.Len(t, entries, 3)
+		assert.Zero(t, entries[1].PC)
+	})
+
+	t.Run("should pass name of the last suppressed entry in summary entry", func(t *testing.T) {
+		next := &fake.Adapter{}
+		adapter := &ratelimit.Adapter{
+			NextAdapter: next,
+			Limits:      map[logger.Level]ratelimit.Limit{logger.ErrorLevel: {Rate: 1}},
+			AfterFunc:   fake.NewClock(entryTime).AfterFunc,
+		}
+		adapter.Log(ctx, entry(logger.ErrorLevel, 0))
+		suppressed := entry(logger.ErrorLevel, 0)
+		suppressed.Name = "payments.db"
+		adapter.Log(ctx, suppressed)
+		// when
+		adapter.Flush()
+		// then
+		entries := next.Entries()
+		require.Len(t, entries, 2)
+		assert.Equal(t, "payments.db", entries[1].Name)
+	})
+
+	t.Run("should pass context of the last suppressed entry without cancellation", func(t *testing.T) {
+		next := &contextAdapterMock{}
+		adapter := &ratelimit.Adapter{
+			NextAdapter: next,
+			Limits:      map[logger.Level]ratelimit.Limit{logger.ErrorLevel: {Rate: 1}},
+			AfterFunc:   fake.NewClock(entryTime).AfterFunc,
+		}
+		adapter.Log(ctx, entry(logger.ErrorLevel, 0))
+		requestCtx, cancel := context.WithCancel(context.WithValue(ctx, ctxKey{}, "value"))
+		adapter.Log(requestCtx, entry(logger.ErrorLevel, 0))
+		cancel()
+		// when
+		adapter.Flush()
+		// then
+		require.Len(t, next.contexts, 2)
+		summaryCtx := next.contexts[1]
+		assert.Equal(t, "value", summaryCtx.Value(ctxKey{}))
+		assert.NoError(t, summaryCtx.Err())
+	})
+
+	t.Run("should pass nil context of the last suppressed entry", func(t *testing.T) {
+		next := &contextAdapterMock{}
+		adapter := &ratelimit.Adapter{
+			NextAdapter: next,
+			Limits:      map[logger.Level]ratelimit.Limit{logger.ErrorLevel: {Rate: 1}},
+			AfterFunc:   fake.NewClock(entryTime).AfterFunc,
+		}
+		adapter.Log(ctx, entry(logger.ErrorLevel, 0))
+		adapter.Log(nil, entry(logger.ErrorLevel, 0)) //nolint:staticcheck // nil context is tested on purpose
+		// when
+		adapter.Flush()
+		// then
+		require.Len(t, next.contexts, 2)
+		assert.Nil(t, next.contexts[1])
+	})
+
+	t.Run("should use separate buckets for each level", func(t *testing.T) {
+		next := &fake.Adapter{}
+		adapter := &ratelimit.Adapter{
+			NextAdapter: next,
+			Limits: map[logger.Level]ratelimit.Limit{
+				logger.WarnLevel:  {Rate: 1},
+				logger.ErrorLevel: {Rate: 1},
+			},
+			AfterFunc: fake.NewClock(entryTime).AfterFunc,
+		}
+		// when
+		adapter.Log(ctx, entry(logger.WarnLevel, 0))
+		adapter.Log(ctx, entry(logger.ErrorLevel, 0))
+		adapter.Log(ctx, entry(logger.ErrorLevel, 0))
+		// then
+		assert.Len(t, next.Entries(), 2)
+	})
+
+	t.Run("should use separate buckets for each value of key field", func(t *testing.T) {
+		next := &fake.Adapter{}
+		adapter := &ratelimit.Adapter{
+			NextAdapter: next,
+			Limits:      map[logger.Level]ratelimit.Limit{logger.ErrorLevel: {Rate: 1}},
+			AfterFunc:   fake.NewClock(entryTime).AfterFunc,
+			KeyField:    "component",
+		}
+		// when
+		adapter.Log(ctx, entry(logger.ErrorLevel, 0, logger.String("component", "db")))
+		adapter.Log(ctx, entry(logger.ErrorLevel, 0, logger.String("component", "http")))
+		adapter.Log(ctx, entry(logger.ErrorLevel, 0, logger.String("component", "db")))
+		adapter.Log(ctx, entry(logger.ErrorLevel, time.Second, logger.String("component", "db")))
+		// then
+		entries := next.Entries()
+		require.Len(t, entries, 4)
+		expectedSummaryFields := []logger.Field{
+			logger.String("component", "db"),
+			logger.Uint64(ratelimit.SuppressedKey, 1),
+		}
+		assert.Equal(t, expectedSummaryFields, entries[2].Fields)
+	})
+
+	t.Run("should skip one more caller frame", func(t *testing.T) {
+		next := &fake.Adapter{}
+		adapter := &ratelimit.Adapter{
+			NextAdapter: next,
+			Limits:      map[logger.Level]ratelimit.Limit{logger.ErrorLevel: {Rate: 1}},
+			AfterFunc:   fake.NewClock(entryTime).AfterFunc,
+		}
+		// when
+		adapter.Log(ctx, logger.Entry{Level: logger.ErrorLevel, Message: message, SkippedCallerFrames: 1})
+		// then
+		entries := next.Entries()
+		require.Len(t, entries, 1)
+		assert.Equal(t, 2, entries[0].SkippedCallerFrames)
+	})
+}
+
+func TestAdapter_Sync(t *testing.T) {
+	t.Run("should pass pending summary entries", func(t *testing.T) {
+		next := &fake.Adapter{}
+		adapter := &ratelimit.Adapter{
+			NextAdapter: next,
+			Limits:      map[logger.Level]ratelimit.Limit{logger.ErrorLevel: {Rate: 1}},
+			AfterFunc:   fake.NewClock(entryTime).AfterFunc,
+		}
+		adapter.Log(ctx, entry(logger.ErrorLevel, 0))
+		adapter.Log(ctx, entry(logger.ErrorLevel, 0))
+		// when
+		err := adapter.Sync()
+		// then
+		require.NoError(t, err)
+		entries := next.Entries()
+		require.Len(t, entries, 2)
+		assert.Equal(t, "suppressed 1 entries", entries[1].Message)
+	})
+}
+
+func TestAdapter_Close(t *testing.T) {
+	t.Run("should pass pending summary entries", func(t *testing.T) {
+		next := &fake.Adapter{}
+		adapter := &ratelimit.Adapter{
+			NextAdapter: next,
+			Limits:      map[logger.Level]ratelimit.Limit{logger.ErrorLevel: {Rate: 1}},
+			AfterFunc:   fake.NewClock(entryTime).AfterFunc,
+		}
+		adapter.Log(ctx, entry(logger.ErrorLevel, 0))
+		adapter.Log(ctx, entry(logger.ErrorLevel, 0))
+		// when
+		err := adapter.Close()
+		// then
+		require.NoError(t, err)
+		entries := next.Entries()
+		require.Len(t, entries, 2)
+		assert.Equal(t, "suppressed 1 entries", entries[1].Message)
+	})
+}
+
+func TestAdapter_Middleware(t *testing.T) {
+	adaptertest.RunMiddleware(t, adaptertest.Middleware{
+		NewAdapter: func(next logger.Adapter) logger.Adapter {
+			return &ratelimit.Adapter{NextAdapter: next}
+		},
+	})
+}
+
+func entry(level logger.Level, elapsed time.Duration, fields ...logger.Field) logger.Entry {
+	return logger.Entry{
+		Level:   level,
+		Message: message,
+		Time:    entryTime.Add(elapsed),
+		Fields:  fields,
+	}
+}
+
+type ctxKey struct{}
+
+type contextAdapterMock struct {
+	contexts []context.Context
+}
+
+func (a *contextAdapterMock) Log(ctx context.Context, _ logger.Entry) {
+	a.contexts = append(a.contexts, ctx)
+}
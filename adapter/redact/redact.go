@@ -0,0 +1,228 @@
+// (c) 2022 Jacek Olszak
+// This code is licensed under MIT license (see LICENSE for details)
+
+// Package redact provides middleware adapter removing sensitive data, such as passwords, tokens or emails, from
+// logged entries.
+package redact
+
+import (
+	"context"
+	"errors"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/elgopher/yala/logger"
+)
+
+// DefaultKeys are patterns of field keys which usually have sensitive values.
+var DefaultKeys = []string{
+	"password", "passwd", "secret", "authorization", "cookie", "token", "*_token", "api_key", "apikey",
+}
+
+var (
+	// CardNumber matches payment card numbers - 13 to 19 digits starting with 2-6, optionally separated with spaces
+	// or dashes.
+	CardNumber = regexp.MustCompile(`\b[2-6](?:[ -]?\d){12,18}\b`)
+	// JWT matches JSON Web Tokens.
+	JWT = regexp.MustCompile(`\beyJ[A-Za-z0-9_-]*\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`)
+	// Email matches email addresses.
+	Email = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+)
+
+// DefaultValues are regular expressions matching commonly logged sensitive values.
+var DefaultValues = []*regexp.Regexp{CardNumber, JWT, Email}
+
+// Adapter is a middleware (decorator) adapter which replaces sensitive data with logger.Redacted:
+//
+//   - values of fields with keys matching Keys,
+//   - parts of string fields, message and error text matching Values.
+//
+// Please note that only string values (and errors) are checked against Values. Other values, such as structs, are
+// passed as is. Use logger.Secret to hide such values.
+type Adapter struct {
+	NextAdapter logger.Adapter
+	// Keys are case-insensitive patterns of field keys, matched using path.Match, for example "*_token" matches
+	// "access_token". For fields nested in groups, only the key of the field is matched. See DefaultKeys.
+	Keys []string
+	// Values are regular expressions matching sensitive parts of string values. See DefaultValues.
+	Values []*regexp.Regexp
+}
+
+// Log redacts the entry and passes it to the next adapter.
+func (a Adapter) Log(ctx context.Context, entry logger.Entry) {
+	if a.NextAdapter == nil {
+		return
+	}
+
+	entry.SkippedCallerFrames++ // each middleware adapter must additionally skip one frame
+	entry.Message = a.redactString(entry.Message)
+	entry.Fields = a.redactFields(entry.Fields)
+	entry.Error = a.redactError(entry.Error)
+
+	a.NextAdapter.Log(ctx, entry)
+}
+
+// redactFields returns fields with sensitive values replaced. The slice is copied only when needed, because it may
+// be read by other goroutines.
+func (a Adapter) redactFields(fields []logger.Field) []logger.Field {
+	var redacted []logger.Field
+
+	for i, field := range fields {
+		newField, changed := a.redactField(field)
+		if !changed {
+			continue
+		}
+
+		if redacted == nil {
+			redacted = make([]logger.Field, len(fields))
+			copy(redacted, fields)
+		}
+
+		redacted[i] = newField
+	}
+
+	if redacted == nil {
+		return fields
+	}
+
+	return redacted
+}
+
+func (a Adapter) redactField(field logger.Field) (redacted logger.Field, changed bool) {
+	if field.Kind() == logger.KindGroup {
+		return field, false
+	}
+
+	if a.keyMatches(field.Key) {
+		return logger.String(field.Key, logger.Redacted), true
+	}
+
+	switch field.Kind() {
+	case logger.KindString:
+		if s := field.StringValue(); a.valueMatches(s) {
+			return logger.String(field.Key, a.redactString(s)), true
+		}
+	case logger.KindError:
+		if err := field.ErrorValue(); a.valueMatches(err.Error()) {
+			return logger.Err(field.Key, a.redactError(err)), true
+		}
+	case logger.KindAny:
+		switch value := field.Value.(type) {
+		case string:
+			if a.valueMatches(value) {
+				return logger.Field{Key: field.Key, Value: a.redactString(value)}, true
+			}
+		case error:
+			if a.valueMatches(value.Error()) {
+				return logger.Field{Key: field.Key, Value: a.redactError(value)}, true
+			}
+		}
+	}
+
+	return field, false
+}
+
+func (a Adapter) keyMatches(key string) bool {
+	key = strings.ToLower(key)
+
+	for _, pattern := range a.Keys {
+		if matched, _ := path.Match(strings.ToLower(pattern), key); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (a Adapter) valueMatches(s string) bool {
+	for _, value := range a.Values {
+		if value.MatchString(s) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (a Adapter) redactString(s string) string {
+	for _, value := range a.Values {
+		s = value.ReplaceAllLiteralString(s, logger.Redacted)
+	}
+
+	return s
+}
+
+// redactError returns the error with redacted text. The original error is returned, if its text does not contain
+// sensitive data.
+func (a Adapter) redactError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	message := err.Error()
+	if !a.valueMatches(message) {
+		return err
+	}
+
+	redacted := &redactedError{
+		message: a.redactString(message),
+		err:     err,
+	}
+
+	for _, cause := range logger.ErrorCauses(err) {
+		redacted.causes = append(redacted.causes, a.redactError(cause))
+	}
+
+	return redacted
+}
+
+// redactedError hides the text of the original error. It does not unwrap the original error, so adapters cannot log
+// it. But it is still possible to check the original error using errors.Is and errors.As, and its type is reported
+// by logger.ErrorType.
+type redactedError struct {
+	message string
+	err     error
+	causes  []error // redacted causes of the original error, see logger.ErrorCauses
+}
+
+func (e *redactedError) Error() string {
+	return e.message
+}
+
+func (e *redactedError) Is(target error) bool {
+	return errors.Is(e.err, target)
+}
+
+func (e *redactedError) As(target interface{}) bool {
+	return errors.As(e.err, target)
+}
+
+// ErrorType returns the type of the original error. See logger.ErrorType.
+func (e *redactedError) ErrorType() string {
+	return logger.ErrorType(e.err)
+}
+
+func (e *redactedError) Unwrap() []error {
+	return e.causes
+}
+
+// Enabled passes the check to the next adapter. See logger.LevelEnabler.
+func (a Adapter) Enabled(ctx context.Context, level logger.Level) bool {
+	return a.NextAdapter != nil && logger.Enabled(ctx, a.NextAdapter, level)
+}
+
+// ReportsCaller passes the check to the next adapter. See logger.CallerReporter.
+func (a Adapter) ReportsCaller() bool {
+	return logger.ReportsCaller(a.NextAdapter)
+}
+
+// Sync passes the call to the next adapter. See logger.Syncer.
+func (a Adapter) Sync() error {
+	return logger.Sync(a.NextAdapter)
+}
+
+// Close passes the call to the next adapter. See logger.Closer.
+func (a Adapter) Close() error {
+	return logger.Close(a.NextAdapter)
+}
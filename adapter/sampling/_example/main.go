@@ -0,0 +1,30 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/elgopher/yala/adapter/console"
+	"github.com/elgopher/yala/adapter/sampling"
+	"github.com/elgopher/yala/logger"
+)
+
+// This example shows how to sample noisy messages
+func main() {
+	ctx := context.Background()
+
+	// create middleware adapter which passes first 3 messages each second, and then every 10th message:
+	adapter := &sampling.Adapter{
+		NextAdapter: console.StdoutAdapter(),
+		First:       3,
+		Thereafter:  10,
+	}
+
+	log := logger.WithAdapter(adapter)
+
+	for i := 0; i < 100; i++ {
+		log.With("i", i).Info(ctx, "Hot path message")
+	}
+
+	fmt.Println("Dropped messages:", adapter.Dropped())
+}
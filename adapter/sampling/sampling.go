@@ -0,0 +1,155 @@
+// (c) 2022 Jacek Olszak
+// This code is licensed under MIT license (see LICENSE for details)
+
+// Package sampling provides middleware adapter sampling logged entries. It is modeled on zap's sampler, but can be
+// used with any adapter.
+package sampling
+
+import (
+	"context"
+	"hash/fnv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/elgopher/yala/logger"
+)
+
+const (
+	countersPerLevel = 4096
+	numberOfLevels   = int(logger.ErrorLevel-logger.DebugLevel) + 1
+)
+
+// Adapter is a middleware (decorator) adapter which samples entries before passing them to NextAdapter. Entries are
+// counted per level and message. In each Tick, the First entries are passed, and thereafter every Thereafter-th entry.
+// The rest is dropped. Entries with the same level are counted using a fixed number of counters indexed by the hash
+// of message, therefore two different messages may be sampled together from time to time.
+//
+// The time of the entry (or the current time, if the entry has no time) is used to determine the Tick.
+// Entries with custom levels (other than logger.DebugLevel, InfoLevel, WarnLevel and ErrorLevel) are not sampled.
+//
+// Adapter must not be copied after first use.
+type Adapter struct {
+	NextAdapter logger.Adapter
+	// Tick is the interval after which counters are reset. Zero value is one second.
+	Tick time.Duration
+	// First is the number of entries with the same level and message passed in each Tick.
+	First uint64
+	// Thereafter defines which entries are passed after First entries. For example, 100 means every 100th entry.
+	// When zero, all entries after First are dropped.
+	Thereafter uint64
+	// OnDrop is an optional hook called synchronously for each dropped entry.
+	OnDrop func(ctx context.Context, entry logger.Entry)
+
+	initCounters sync.Once
+	counters     *[numberOfLevels][countersPerLevel]counter
+	dropped      atomic.Uint64
+}
+
+// Log passes the entry to the next adapter or drops it.
+func (a *Adapter) Log(ctx context.Context, entry logger.Entry) {
+	if a.NextAdapter == nil {
+		return
+	}
+
+	entry.SkippedCallerFrames++ // each middleware adapter must additionally skip one frame
+
+	if !a.sample(entry) {
+		a.dropped.Add(1)
+
+		if a.OnDrop != nil {
+			a.OnDrop(ctx, entry)
+		}
+
+		return
+	}
+
+	a.NextAdapter.Log(ctx, entry)
+}
+
+// sample returns true if entry should be passed to the next adapter.
+func (a *Adapter) sample(entry logger.Entry) bool {
+	level := int(entry.Level - logger.DebugLevel)
+	if level < 0 || level >= numberOfLevels {
+		return true
+	}
+
+	a.initCounters.Do(func() {
+		a.counters = &[numberOfLevels][countersPerLevel]counter{}
+	})
+
+	entryTime := entry.Time
+	if entryTime.IsZero() {
+		entryTime = time.Now()
+	}
+
+	tick := a.Tick
+	if tick == 0 {
+		tick = time.Second
+	}
+
+	c := &a.counters[level][hash(entry.Message)%countersPerLevel]
+	n := c.incCheckReset(entryTime, tick)
+
+	if n <= a.First {
+		return true
+	}
+
+	return a.Thereafter > 0 && (n-a.First)%a.Thereafter == 0
+}
+
+// Dropped returns the number of entries dropped so far.
+func (a *Adapter) Dropped() uint64 {
+	return a.dropped.Load()
+}
+
+// Enabled passes the check to the next adapter. See logger.LevelEnabler.
+func (a *Adapter) Enabled(ctx context.Context, level logger.Level) bool {
+	return a.NextAdapter != nil && logger.Enabled(ctx, a.NextAdapter, level)
+}
+
+// ReportsCaller passes the check to the next adapter. See logger.CallerReporter.
+func (a *Adapter) ReportsCaller() bool {
+	return logger.ReportsCaller(a.NextAdapter)
+}
+
+// Sync passes the call to the next adapter. See logger.Syncer.
+func (a *Adapter) Sync() error {
+	return logger.Sync(a.NextAdapter)
+}
+
+// Close passes the call to the next adapter. See logger.Closer.
+func (a *Adapter) Close() error {
+	return logger.Close(a.NextAdapter)
+}
+
+func hash(message string) uint32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(message))
+
+	return h.Sum32()
+}
+
+type counter struct {
+	resetAt atomic.Int64
+	count   atomic.Uint64
+}
+
+// incCheckReset increments the counter and returns its new value. The counter is reset when the tick has passed.
+func (c *counter) incCheckReset(t time.Time, tick time.Duration) uint64 {
+	now := t.UnixNano()
+
+	resetAt := c.resetAt.Load()
+	if resetAt > now {
+		return c.count.Add(1)
+	}
+
+	c.count.Store(1)
+
+	if !c.resetAt.CompareAndSwap(resetAt, now+tick.Nanoseconds()) {
+		// someone else reset the counter
+		return c.count.Add(1)
+	}
+
+	return 1
+}
@@ -0,0 +1,192 @@
+// (c) 2022 Jacek Olszak
+// This code is licensed under MIT license (see LICENSE for details)
+
+package sampling_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/elgopher/yala/adapter/internal/adaptertest"
+	"github.com/elgopher/yala/adapter/internal/fake"
+	"github.com/elgopher/yala/adapter/sampling"
+	"github.com/elgopher/yala/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const message = "message"
+
+var ctx = context.Background()
+
+var entryTime = time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func TestAdapter_Log(t *testing.T) {
+	t.Run("should not panic when next adapter is nil", func(t *testing.T) {
+		adapter := &sampling.Adapter{}
+		assert.NotPanics(t, func() {
+			adapter.Log(ctx, logger.Entry{Level: logger.InfoLevel, Message: message})
+		})
+	})
+
+	t.Run("should pass first entries and thereafter every Mth entry", func(t *testing.T) {
+		tests := map[string]struct {
+			first, thereafter uint64
+			expectedPassed    []int
+		}{
+			"first only": {
+				first:          2,
+				expectedPassed: []int{1, 2},
+			},
+			"first and thereafter": {
+				first:          2,
+				thereafter:     3,
+				expectedPassed: []int{1, 2, 5, 8},
+			},
+			"thereafter only": {
+				thereafter:     4,
+				expectedPassed: []int{4, 8},
+			},
+		}
+
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				next := &fake.Adapter{}
+				adapter := &sampling.Adapter{NextAdapter: next, First: test.first, Thereafter: test.thereafter}
+				// when
+				for i := 1; i <= 9; i++ {
+					adapter.Log(ctx, entry(logger.InfoLevel, message, i))
+				}
+				// then
+				assert.Equal(t, test.expectedPassed, numbers(next.Entries()))
+				assert.Equal(t, uint64(9-len(test.expectedPassed)), adapter.Dropped())
+			})
+		}
+	})
+
+	t.Run("should reset counters after tick", func(t *testing.T) {
+		next := &fake.Adapter{}
+		adapter := &sampling.Adapter{NextAdapter: next, First: 1, Tick: time.Minute}
+		adapter.Log(ctx, entry(logger.InfoLevel, message, 1))
+		adapter.Log(ctx, entry(logger.InfoLevel, message, 2))
+		e := entry(logger.InfoLevel, message, 3)
+		e.Time = e.Time.Add(time.Minute)
+		// when
+		adapter.Log(ctx, e)
+		// then
+		assert.Equal(t, []int{1, 3}, numbers(next.Entries()))
+	})
+
+	t.Run("should count entries with different levels separately", func(t *testing.T) {
+		next := &fake.Adapter{}
+		adapter := &sampling.Adapter{NextAdapter: next, First: 1}
+		// when
+		adapter.Log(ctx, entry(logger.InfoLevel, message, 1))
+		adapter.Log(ctx, entry(logger.ErrorLevel, message, 2))
+		adapter.Log(ctx, entry(logger.InfoLevel, message, 3))
+		// then
+		assert.Equal(t, []int{1, 2}, numbers(next.Entries()))
+	})
+
+	t.Run("should count entries with different messages separately", func(t *testing.T) {
+		next := &fake.Adapter{}
+		adapter := &sampling.Adapter{NextAdapter: next, First: 1}
+		// when
+		adapter.Log(ctx, entry(logger.InfoLevel, "a", 1))
+		adapter.Log(ctx, entry(logger.InfoLevel, "b", 2))
+		adapter.Log(ctx, entry(logger.InfoLevel, "a", 3))
+		// then
+		assert.Equal(t, []int{1, 2}, numbers(next.Entries()))
+	})
+
+	t.Run("should not sample entries with custom level", func(t *testing.T) {
+		next := &fake.Adapter{}
+		adapter := &sampling.Adapter{NextAdapter: next}
+		// when
+		adapter.Log(ctx, entry(logger.ErrorLevel+1, message, 1))
+		// then
+		assert.Len(t, next.Entries(), 1)
+	})
+
+	t.Run("should call OnDrop hook for dropped entries", func(t *testing.T) {
+		var dropped []logger.Entry
+
+		adapter := &sampling.Adapter{
+			NextAdapter: &fake.Adapter{},
+			First:       1,
+			OnDrop: func(ctx context.Context, entry logger.Entry) {
+				dropped = append(dropped, entry)
+			},
+		}
+		// when
+		adapter.Log(ctx, entry(logger.InfoLevel, message, 1))
+		adapter.Log(ctx, entry(logger.InfoLevel, message, 2))
+		// then
+		assert.Equal(t, []int{2}, numbers(dropped))
+	})
+
+	t.Run("should skip one more caller frame", func(t *testing.T) {
+		next := &fake.Adapter{}
+		adapter := &sampling.Adapter{NextAdapter: next, First: 1}
+		// when
+		adapter.Log(ctx, logger.Entry{Level: logger.InfoLevel, Message: message, SkippedCallerFrames: 1})
+		// then
+		entries := next.Entries()
+		require.Len(t, entries, 1)
+		assert.Equal(t, 2, entries[0].SkippedCallerFrames)
+	})
+
+	t.Run("should count entries logged concurrently", func(t *testing.T) {
+		next := &fake.Adapter{}
+		adapter := &sampling.Adapter{NextAdapter: next, First: 10, Tick: time.Hour}
+
+		var wg sync.WaitGroup
+
+		const goroutines = 10
+
+		wg.Add(goroutines)
+		// when
+		for i := 0; i < goroutines; i++ {
+			go func() {
+				defer wg.Done()
+
+				for j := 0; j < 10; j++ {
+					adapter.Log(ctx, logger.Entry{Level: logger.InfoLevel, Message: message})
+				}
+			}()
+		}
+
+		wg.Wait()
+		// then
+		assert.Len(t, next.Entries(), 10)
+		assert.Equal(t, uint64(90), adapter.Dropped())
+	})
+}
+
+func TestAdapter_Middleware(t *testing.T) {
+	adaptertest.RunMiddleware(t, adaptertest.Middleware{
+		NewAdapter: func(next logger.Adapter) logger.Adapter {
+			return &sampling.Adapter{NextAdapter: next}
+		},
+	})
+}
+
+func entry(level logger.Level, msg string, number int) logger.Entry {
+	return logger.Entry{
+		Level:   level,
+		Message: msg,
+		Time:    entryTime,
+		Fields:  []logger.Field{logger.Int("number", number)},
+	}
+}
+
+func numbers(entries []logger.Entry) []int {
+	var n []int
+	for _, e := range entries {
+		n = append(n, int(e.Fields[0].Int64Value()))
+	}
+
+	return n
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+
+	"github.com/elgopher/yala/adapter/slogadapter"
+	"github.com/elgopher/yala/logger"
+)
+
+var ErrSome = errors.New("ErrSome")
+
+// This example shows how to use yala with log/slog package
+func main() {
+	ctx := context.Background()
+
+	handler := slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		AddSource: true,
+		Level:     slog.LevelDebug,
+	})
+	adapter := slogadapter.Adapter{Handler: handler} // create logger.Adapter for slog
+	log := logger.WithAdapter(adapter)               // Create yala logger
+
+	log.Debug(ctx, "Hello slog")
+
+	log.InfoFields(ctx, "Some info", logger.Fields{
+		"field_name": "field_value",
+		"other_name": "field_value",
+	})
+
+	log.WarnFields(ctx, "Deprecated configuration parameter. It will be removed.", logger.Fields{
+		"parameter": "some",
+	})
+
+	log.ErrorCause(ctx, "Some error", ErrSome)
+}
@@ -0,0 +1,156 @@
+// (c) 2022 Jacek Olszak
+// This code is licensed under MIT license (see LICENSE for details)
+
+// Package slogadapter provides yala adapter which leverages log/slog package (https://pkg.go.dev/log/slog).
+package slogadapter
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+	"time"
+
+	"github.com/elgopher/yala/logger"
+)
+
+// Adapter is a logger.Adapter implementation, which is using `log/slog` package (https://pkg.go.dev/log/slog).
+type Adapter struct {
+	Handler slog.Handler
+}
+
+// Log logs the entry using slog.Handler. Error is logged as an attribute with "error" key, along with its concrete
+// type ("errorType") and messages of errors joined in it ("errorCauses").
+func (a Adapter) Log(ctx context.Context, entry logger.Entry) {
+	if a.Handler == nil {
+		return
+	}
+
+	level := convertLevel(entry.Level)
+	if !a.Handler.Enabled(ctx, level) {
+		return
+	}
+
+	pc := entry.PC
+	if pc == 0 {
+		pc = callerPC(entry.SkippedCallerFrames)
+	}
+
+	entryTime := entry.Time
+	if entryTime.IsZero() {
+		entryTime = time.Now()
+	}
+
+	record := slog.NewRecord(entryTime, level, entry.Message, pc)
+
+	if entry.Name != "" {
+		record.AddAttrs(slog.String(logger.NameKey, entry.Name))
+	}
+
+	for i, field := range entry.Fields {
+		if field.Kind() == logger.KindGroup {
+			record.AddAttrs(slog.Attr{Key: field.Key, Value: groupValue(entry.Fields[i+1:])})
+
+			break
+		}
+
+		record.AddAttrs(attr(field))
+	}
+
+	if entry.Error != nil {
+		record.AddAttrs(errorAttrs(entry.Error)...)
+	}
+
+	_ = a.Handler.Handle(ctx, record)
+}
+
+// Enabled returns true if slog.Handler is configured to log messages with given level.
+func (a Adapter) Enabled(ctx context.Context, level logger.Level) bool {
+	if a.Handler == nil {
+		return false
+	}
+
+	return a.Handler.Enabled(ctx, convertLevel(level))
+}
+
+// ReportsCaller returns true, because Entry.PC is passed to slog.Handler as the PC of slog.Record.
+// See logger.CallerReporter.
+func (a Adapter) ReportsCaller() bool {
+	return a.Handler != nil
+}
+
+func errorAttrs(err error) []slog.Attr {
+	attrs := []slog.Attr{slog.Any("error", err), slog.String("errorType", logger.ErrorType(err))}
+
+	if causes := logger.ErrorCauses(err); len(causes) > 0 {
+		messages := make([]string, len(causes))
+		types := make([]string, len(causes))
+
+		for i, cause := range causes {
+			messages[i] = cause.Error()
+			types[i] = logger.ErrorType(cause)
+		}
+
+		attrs = append(attrs, slog.Any("errorCauses", messages), slog.Any("errorCauseTypes", types))
+	}
+
+	return attrs
+}
+
+// groupValue converts fields nested in a group into slog.Value with slog.KindGroup.
+func groupValue(fields []logger.Field) slog.Value {
+	attrs := make([]slog.Attr, 0, len(fields))
+
+	for i, field := range fields {
+		if field.Kind() == logger.KindGroup {
+			attrs = append(attrs, slog.Attr{Key: field.Key, Value: groupValue(fields[i+1:])})
+
+			break
+		}
+
+		attrs = append(attrs, attr(field))
+	}
+
+	return slog.GroupValue(attrs...)
+}
+
+func attr(field logger.Field) slog.Attr {
+	switch field.Kind() {
+	case logger.KindString:
+		return slog.String(field.Key, field.StringValue())
+	case logger.KindInt64:
+		return slog.Int64(field.Key, field.Int64Value())
+	case logger.KindUint64:
+		return slog.Uint64(field.Key, field.Uint64Value())
+	case logger.KindFloat64:
+		return slog.Float64(field.Key, field.Float64Value())
+	case logger.KindBool:
+		return slog.Bool(field.Key, field.BoolValue())
+	case logger.KindDuration:
+		return slog.Duration(field.Key, field.DurationValue())
+	case logger.KindTime:
+		return slog.Time(field.Key, field.TimeValue())
+	case logger.KindAny, logger.KindError:
+		return slog.Any(field.Key, field.Value)
+	default:
+		return slog.Any(field.Key, field.AnyValue())
+	}
+}
+
+// convertLevel converts the level by its severity. slog levels are 4 times bigger than logger levels (for example
+// logger.WarnLevel is 1 and slog.LevelWarn is 4), so custom levels are converted too, for example logger.Level(3)
+// becomes slog.LevelError+4.
+func convertLevel(level logger.Level) slog.Level {
+	const slogLevelsPerLevel = 4
+
+	return slog.Level(slogLevelsPerLevel * int(level))
+}
+
+func callerPC(skippedCallerFrames int) uintptr {
+	var pcs [1]uintptr
+
+	const skip = 3 // runtime.Callers, callerPC and Adapter.Log
+
+	runtime.Callers(skippedCallerFrames+skip, pcs[:])
+
+	return pcs[0]
+}
@@ -0,0 +1,20 @@
+// (c) 2022 Jacek Olszak
+// This code is licensed under MIT license (see LICENSE for details)
+
+package slogadapter_test
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/elgopher/yala/adapter/internal/benchmark"
+	"github.com/elgopher/yala/adapter/slogadapter"
+)
+
+func BenchmarkSlog(b *testing.B) {
+	adapter := slogadapter.Adapter{
+		Handler: slog.NewJSONHandler(benchmark.DiscardWriter{}, nil),
+	}
+
+	benchmark.Adapter(b, adapter)
+}
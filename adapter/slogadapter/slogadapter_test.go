@@ -0,0 +1,358 @@
+// (c) 2022 Jacek Olszak
+// This code is licensed under MIT license (see LICENSE for details)
+
+package slogadapter_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/elgopher/yala/adapter/internal/adaptertest"
+	"github.com/elgopher/yala/adapter/slogadapter"
+	"github.com/elgopher/yala/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const message = "message"
+
+func TestAdapter_Log(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should not panic when handler is nil", func(t *testing.T) {
+		adapter := slogadapter.Adapter{Handler: nil}
+		assert.NotPanics(t, func() {
+			adapter.Log(ctx, logger.Entry{
+				Level:   logger.InfoLevel,
+				Message: message,
+			})
+		})
+	})
+
+	t.Run("should convert level by severity", func(t *testing.T) {
+		tests := map[logger.Level]slog.Level{
+			logger.DebugLevel - 1: slog.LevelDebug - 4,
+			logger.DebugLevel:     slog.LevelDebug,
+			logger.InfoLevel:      slog.LevelInfo,
+			logger.WarnLevel:      slog.LevelWarn,
+			logger.ErrorLevel:     slog.LevelError,
+			logger.ErrorLevel + 1: slog.LevelError + 4,
+		}
+
+		for level, expectedLevel := range tests {
+			t.Run(level.String(), func(t *testing.T) {
+				handler := &handlerMock{}
+				adapter := slogadapter.Adapter{Handler: handler}
+				// when
+				adapter.Log(ctx, logger.Entry{Level: level, Message: message})
+				// then
+				require.Len(t, handler.records, 1)
+				assert.Equal(t, expectedLevel, handler.records[0].Level)
+			})
+		}
+	})
+
+	t.Run("should log caller", func(t *testing.T) {
+		var builder strings.Builder
+		adapter := newAdapter(&builder)
+		// when
+		adapter.Log(ctx, logger.Entry{
+			Level:   logger.InfoLevel,
+			Message: message,
+		})
+		// then
+		msg := unmarshalSlogMessage(t, builder.String())
+		assert.Equal(t, "slogadapter_test.go", filepath.Base(msg.Source.File))
+	})
+
+	t.Run("should log caller using logger", func(t *testing.T) {
+		var builder strings.Builder
+		log := logger.WithAdapter(newAdapter(&builder))
+		// when
+		log.Info(ctx, message)
+		// then
+		msg := unmarshalSlogMessage(t, builder.String())
+		assert.Equal(t, "slogadapter_test.go", filepath.Base(msg.Source.File))
+	})
+
+	t.Run("should pass caller PC captured by logger to handler", func(t *testing.T) {
+		handler := &handlerMock{}
+		log := logger.WithAdapter(slogadapter.Adapter{Handler: handler})
+		// when
+		log.Info(ctx, message)
+		_, _, line, _ := runtime.Caller(0)
+		// then
+		require.Len(t, handler.records, 1)
+		pc := handler.records[0].PC
+		require.NotZero(t, pc)
+		frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+		assert.Equal(t, "slogadapter_test.go", filepath.Base(frame.File))
+		assert.Equal(t, line-1, frame.Line)
+	})
+
+	t.Run("should pass context to handler", func(t *testing.T) {
+		type key struct{}
+
+		handler := &handlerMock{}
+		adapter := slogadapter.Adapter{Handler: handler}
+		ctxWithValue := context.WithValue(ctx, key{}, "value")
+		// when
+		adapter.Log(ctxWithValue, logger.Entry{
+			Level:   logger.InfoLevel,
+			Message: message,
+		})
+		// then
+		require.Len(t, handler.contexts, 1)
+		assert.Equal(t, "value", handler.contexts[0].Value(key{}))
+	})
+
+	t.Run("should log fields nested in groups", func(t *testing.T) {
+		var builder strings.Builder
+		adapter := slogadapter.Adapter{Handler: slog.NewJSONHandler(&builder, nil)}
+		entry := logger.Entry{
+			Level:   logger.ErrorLevel,
+			Message: message,
+			Fields: []logger.Field{
+				logger.String("k1", "v1"),
+				logger.Group("http"),
+				logger.Int("status", 200),
+				logger.Group("db"),
+				logger.String("k2", "v2"),
+			},
+			Error: errors.New("err"),
+		}
+		// when
+		adapter.Log(ctx, entry)
+		// then
+		var out map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(builder.String()), &out))
+		assert.Equal(t, "v1", out["k1"])
+		assert.Equal(t, "err", out["error"])
+		expectedGroup := map[string]interface{}{
+			"status": 200.0,
+			"db": map[string]interface{}{
+				"k2": "v2",
+			},
+		}
+		assert.Equal(t, expectedGroup, out["http"])
+	})
+
+	t.Run("should log joined errors with type and causes with their types", func(t *testing.T) {
+		var builder strings.Builder
+		adapter := slogadapter.Adapter{Handler: slog.NewJSONHandler(&builder, nil)}
+		// when
+		adapter.Log(ctx, logger.Entry{
+			Level:   logger.ErrorLevel,
+			Message: message,
+			Error:   errors.Join(errors.New("a"), errors.New("b")),
+		})
+		// then
+		var out map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(builder.String()), &out))
+		assert.Equal(t, "a\nb", out["error"])
+		assert.Equal(t, "*errors.joinError", out["errorType"])
+		assert.Equal(t, []interface{}{"a", "b"}, out["errorCauses"])
+		assert.Equal(t, []interface{}{"*errors.errorString", "*errors.errorString"}, out["errorCauseTypes"])
+	})
+
+	t.Run("should log entry name", func(t *testing.T) {
+		var builder strings.Builder
+		adapter := slogadapter.Adapter{Handler: slog.NewJSONHandler(&builder, nil)}
+		// when
+		adapter.Log(ctx, logger.Entry{
+			Level:   logger.InfoLevel,
+			Message: message,
+			Name:    "payments.db",
+		})
+		// then
+		var out map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(builder.String()), &out))
+		assert.Equal(t, "payments.db", out[logger.NameKey])
+	})
+
+	t.Run("should pass entry PC to handler", func(t *testing.T) {
+		handler := &handlerMock{}
+		adapter := slogadapter.Adapter{Handler: handler}
+		const pc = 1234
+		// when
+		adapter.Log(ctx, logger.Entry{
+			Level:   logger.InfoLevel,
+			Message: message,
+			PC:      pc,
+		})
+		// then
+		require.Len(t, handler.records, 1)
+		assert.Equal(t, uintptr(pc), handler.records[0].PC)
+	})
+
+	t.Run("should pass entry time to handler", func(t *testing.T) {
+		handler := &handlerMock{}
+		adapter := slogadapter.Adapter{Handler: handler}
+		entryTime := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+		// when
+		adapter.Log(ctx, logger.Entry{
+			Level:   logger.InfoLevel,
+			Message: message,
+			Time:    entryTime,
+		})
+		// then
+		require.Len(t, handler.records, 1)
+		assert.Equal(t, entryTime, handler.records[0].Time)
+	})
+
+	t.Run("should pass current time to handler when entry time is zero", func(t *testing.T) {
+		handler := &handlerMock{}
+		adapter := slogadapter.Adapter{Handler: handler}
+		// when
+		adapter.Log(ctx, logger.Entry{
+			Level:   logger.InfoLevel,
+			Message: message,
+		})
+		// then
+		require.Len(t, handler.records, 1)
+		assert.False(t, handler.records[0].Time.IsZero())
+	})
+
+	t.Run("should not log message when level is disabled", func(t *testing.T) {
+		var builder strings.Builder
+		handler := slog.NewJSONHandler(&builder, &slog.HandlerOptions{Level: slog.LevelInfo})
+		adapter := slogadapter.Adapter{Handler: handler}
+		// when
+		adapter.Log(ctx, logger.Entry{
+			Level:   logger.DebugLevel,
+			Message: message,
+		})
+		// then
+		assert.Empty(t, builder.String())
+	})
+
+	adaptertest.Run(t, adaptertest.Subject{
+		NewAdapter:       newAdapter,
+		UnmarshalMessage: unmarshalMessage,
+	})
+}
+
+func TestAdapter_Enabled(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should return false when handler is nil", func(t *testing.T) {
+		adapter := slogadapter.Adapter{Handler: nil}
+		assert.False(t, adapter.Enabled(ctx, logger.ErrorLevel))
+	})
+
+	t.Run("should use handler level", func(t *testing.T) {
+		handler := slog.NewJSONHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelWarn})
+		adapter := slogadapter.Adapter{Handler: handler}
+		assert.False(t, adapter.Enabled(ctx, logger.DebugLevel))
+		assert.False(t, adapter.Enabled(ctx, logger.InfoLevel))
+		assert.True(t, adapter.Enabled(ctx, logger.WarnLevel))
+		assert.True(t, adapter.Enabled(ctx, logger.ErrorLevel))
+	})
+}
+
+func TestAdapter_ReportsCaller(t *testing.T) {
+	t.Run("should return false when handler is nil", func(t *testing.T) {
+		adapter := slogadapter.Adapter{Handler: nil}
+		assert.False(t, adapter.ReportsCaller())
+	})
+
+	t.Run("should return true", func(t *testing.T) {
+		adapter := slogadapter.Adapter{Handler: &handlerMock{}}
+		assert.True(t, adapter.ReportsCaller())
+	})
+}
+
+func newAdapter(writer io.Writer) logger.Adapter {
+	handler := slog.NewJSONHandler(writer, &slog.HandlerOptions{
+		AddSource: true,
+		Level:     slog.LevelDebug,
+	})
+
+	return slogadapter.Adapter{Handler: handler}
+}
+
+type handlerMock struct {
+	contexts []context.Context
+	records  []slog.Record
+}
+
+func (h *handlerMock) Enabled(context.Context, slog.Level) bool {
+	return true
+}
+
+func (h *handlerMock) Handle(ctx context.Context, record slog.Record) error {
+	h.contexts = append(h.contexts, ctx)
+	h.records = append(h.records, record)
+
+	return nil
+}
+
+func (h *handlerMock) WithAttrs([]slog.Attr) slog.Handler {
+	return h
+}
+
+func (h *handlerMock) WithGroup(string) slog.Handler {
+	return h
+}
+
+var levelMapping = map[string]logger.Level{
+	"DEBUG": logger.DebugLevel,
+	"INFO":  logger.InfoLevel,
+	"WARN":  logger.WarnLevel,
+	"ERROR": logger.ErrorLevel,
+}
+
+func unmarshalMessage(t *testing.T, line string) adaptertest.Message {
+	t.Helper()
+
+	out := unmarshalSlogMessage(t, line)
+
+	return adaptertest.Message{
+		Level:          levelMapping[out.Level],
+		Message:        out.Msg,
+		Error:          out.Error,
+		StringField:    out.StringField,
+		IntField:       out.IntField,
+		Int64Field:     out.Int64Field,
+		Float32Field:   out.Float32Field,
+		Uint64Field:    out.Uint64Field,
+		Float64Field:   out.Float64Field,
+		BoolField:      out.BoolField,
+		TimeField:      out.TimeField,
+		InterfaceField: out.InterfaceField,
+	}
+}
+
+func unmarshalSlogMessage(t *testing.T, line string) slogMessage {
+	t.Helper()
+
+	out := slogMessage{}
+	err := json.Unmarshal([]byte(line), &out)
+	require.NoError(t, err)
+
+	return out
+}
+
+type slogMessage struct {
+	Level          string
+	Msg            string
+	Source         slog.Source
+	Error          string
+	StringField    string
+	IntField       int
+	Int64Field     int64
+	Float32Field   float32
+	Uint64Field    uint64
+	Float64Field   float64
+	BoolField      bool
+	TimeField      time.Time
+	InterfaceField adaptertest.InterfaceField
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+	"log"
+	"log/slog"
+	"os"
+
+	"github.com/elgopher/yala/adapter/logadapter"
+	"github.com/elgopher/yala/adapter/sloghandler"
+	"github.com/elgopher/yala/logger"
+)
+
+// This example shows how to pass messages logged using log/slog to logger.Adapter
+func main() {
+	ctx := context.Background()
+
+	standardLog := log.New(os.Stdout, "", log.LstdFlags|log.Lshortfile)
+	adapter := logadapter.Adapter(standardLog)
+
+	// libraries using yala
+	yalaLogger := logger.WithAdapter(adapter)
+	yalaLogger.Info(ctx, "Hello from yala")
+
+	// libraries using log/slog
+	slog.SetDefault(slog.New(sloghandler.New(adapter)))
+
+	slog.Info("Hello from slog", "field_name", "field_value")
+
+	slog.With("request_id", "123").
+		WithGroup("http").
+		WarnContext(ctx, "Slow request", "status", 200, "path", "/")
+}
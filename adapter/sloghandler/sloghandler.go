@@ -0,0 +1,216 @@
+// (c) 2022 Jacek Olszak
+// This code is licensed under MIT license (see LICENSE for details)
+
+// Package sloghandler provides slog.Handler implementation which passes all records to logger.Adapter. Thanks to that,
+// messages logged by libraries using log/slog end up in the same place as messages logged by libraries using yala.
+package sloghandler
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+
+	"github.com/elgopher/yala/logger"
+)
+
+// Handler is a slog.Handler implementation, which converts slog.Record into logger.Entry and passes it to
+// logger.Adapter.
+//
+// Attributes are converted into fields. Groups added using WithGroup are converted into logger.Group fields, so all
+// subsequent fields are nested in them. Attributes nested in group attributes (for example slog.Group) are converted
+// into fields with dotted keys, for example "group.key".
+type Handler struct {
+	adapter logger.Adapter
+	fields  []logger.Field // fields added using WithAttrs and groups added using WithGroup
+}
+
+// New creates a new Handler passing records to the adapter. If adapter is nil then nothing is logged.
+func New(adapter logger.Adapter) *Handler {
+	return &Handler{adapter: adapter}
+}
+
+// Enabled returns true when adapter is not nil and the level is enabled. See logger.LevelEnabler.
+func (h *Handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.adapter != nil && logger.Enabled(ctx, h.adapter, convertLevel(level))
+}
+
+// Handle converts the record into logger.Entry and passes it to the adapter.
+func (h *Handler) Handle(ctx context.Context, record slog.Record) error {
+	if h.adapter == nil {
+		return nil
+	}
+
+	fields := make([]logger.Field, len(h.fields), len(h.fields)+record.NumAttrs())
+	copy(fields, h.fields)
+
+	record.Attrs(func(attr slog.Attr) bool {
+		fields = appendAttr(fields, "", attr)
+
+		return true
+	})
+
+	fields = withoutTrailingGroups(fields)
+
+	entry := logger.Entry{
+		Level:               convertLevel(record.Level),
+		Message:             record.Message,
+		Time:                record.Time,
+		Fields:              fields,
+		SkippedCallerFrames: skippedCallerFrames(record.PC),
+		PC:                  record.PC,
+	}
+
+	h.adapter.Log(ctx, entry)
+
+	return nil
+}
+
+// WithAttrs returns a new Handler with additional fields.
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+
+	fields := make([]logger.Field, len(h.fields), len(h.fields)+len(attrs))
+	copy(fields, h.fields)
+
+	for _, attr := range attrs {
+		fields = appendAttr(fields, "", attr)
+	}
+
+	newHandler := *h
+	newHandler.fields = fields
+
+	return &newHandler
+}
+
+// WithGroup returns a new Handler which qualifies all subsequent attributes with the group name. See logger.Group.
+func (h *Handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	fields := make([]logger.Field, len(h.fields), len(h.fields)+1)
+	copy(fields, h.fields)
+
+	newHandler := *h
+	newHandler.fields = append(fields, logger.Group(name))
+
+	return &newHandler
+}
+
+// withoutTrailingGroups removes groups which do not contain any field, because slog.Handler must not output such
+// groups. It returns nil if there are no fields left.
+func withoutTrailingGroups(fields []logger.Field) []logger.Field {
+	for len(fields) > 0 && fields[len(fields)-1].Kind() == logger.KindGroup {
+		fields = fields[:len(fields)-1]
+	}
+
+	if len(fields) == 0 {
+		return nil
+	}
+
+	return fields
+}
+
+func appendAttr(fields []logger.Field, prefix string, attr slog.Attr) []logger.Field {
+	attr.Value = attr.Value.Resolve()
+
+	if attr.Equal(slog.Attr{}) {
+		return fields
+	}
+
+	if attr.Value.Kind() == slog.KindGroup {
+		groupPrefix := prefix
+		if attr.Key != "" {
+			groupPrefix = prefix + attr.Key + "."
+		}
+
+		for _, groupAttr := range attr.Value.Group() {
+			fields = appendAttr(fields, groupPrefix, groupAttr)
+		}
+
+		return fields
+	}
+
+	return append(fields, field(prefix+attr.Key, attr.Value))
+}
+
+func field(key string, value slog.Value) logger.Field {
+	switch value.Kind() {
+	case slog.KindString:
+		return logger.String(key, value.String())
+	case slog.KindInt64:
+		return logger.Int64(key, value.Int64())
+	case slog.KindUint64:
+		return logger.Uint64(key, value.Uint64())
+	case slog.KindFloat64:
+		return logger.Float64(key, value.Float64())
+	case slog.KindBool:
+		return logger.Bool(key, value.Bool())
+	case slog.KindDuration:
+		return logger.Duration(key, value.Duration())
+	case slog.KindTime:
+		return logger.Time(key, value.Time())
+	case slog.KindAny, slog.KindGroup, slog.KindLogValuer:
+		if err, ok := value.Any().(error); ok {
+			return logger.Err(key, err)
+		}
+
+		return logger.Any(key, value.Any())
+	default:
+		return logger.Any(key, value.Any())
+	}
+}
+
+func convertLevel(level slog.Level) logger.Level {
+	switch {
+	case level < slog.LevelInfo:
+		return logger.DebugLevel
+	case level < slog.LevelWarn:
+		return logger.InfoLevel
+	case level < slog.LevelError:
+		return logger.WarnLevel
+	default:
+		return logger.ErrorLevel
+	}
+}
+
+const maxStackDepth = 64
+
+// skippedCallerFrames returns the number of frames between Handler.Handle and the function which logged the record.
+// Exact number depends on the slog.Logger method used and therefore must be calculated by finding the record's
+// program counter on the current stack.
+func skippedCallerFrames(pc uintptr) int {
+	if pc == 0 {
+		return 0
+	}
+
+	var pcs [maxStackDepth]uintptr
+
+	const skip = 3 // runtime.Callers, skippedCallerFrames and Handler.Handle
+
+	n := runtime.Callers(skip, pcs[:])
+
+	for i := 0; i < n; i++ {
+		if pcs[i] != pc {
+			continue
+		}
+
+		skipped := 1 // Handler.Handle
+
+		if i == 0 {
+			return skipped
+		}
+
+		// single program counter may represent multiple frames when functions were inlined
+		frames := runtime.CallersFrames(pcs[:i])
+		for more := true; more; skipped++ {
+			_, more = frames.Next()
+		}
+
+		return skipped
+	}
+
+	return 0
+}
@@ -0,0 +1,410 @@
+// (c) 2022 Jacek Olszak
+// This code is licensed under MIT license (see LICENSE for details)
+
+package sloghandler_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"runtime"
+	"strings"
+	"testing"
+	"testing/slogtest"
+	"time"
+
+	"github.com/elgopher/yala/adapter/internal/fake"
+	"github.com/elgopher/yala/adapter/sloghandler"
+	"github.com/elgopher/yala/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const message = "message"
+
+var ctx = context.Background()
+
+func TestHandler_Handle(t *testing.T) {
+	t.Run("should not panic when adapter is nil", func(t *testing.T) {
+		log := slog.New(sloghandler.New(nil))
+		assert.NotPanics(t, func() {
+			log.Info(message)
+		})
+	})
+
+	t.Run("should log message with proper level", func(t *testing.T) {
+		tests := map[slog.Level]logger.Level{
+			slog.LevelDebug - 1: logger.DebugLevel,
+			slog.LevelDebug:     logger.DebugLevel,
+			slog.LevelInfo:      logger.InfoLevel,
+			slog.LevelInfo + 1:  logger.InfoLevel,
+			slog.LevelWarn:      logger.WarnLevel,
+			slog.LevelError:     logger.ErrorLevel,
+			slog.LevelError + 1: logger.ErrorLevel,
+		}
+
+		for slogLevel, expectedLevel := range tests {
+			t.Run(slogLevel.String(), func(t *testing.T) {
+				adapter := &adapterMock{}
+				log := slog.New(sloghandler.New(adapter))
+				// when
+				log.Log(ctx, slogLevel, message)
+				// then
+				entry := adapter.HasExactlyOneEntry(t)
+				assert.Equal(t, expectedLevel, entry.Level)
+				assert.Equal(t, message, entry.Message)
+			})
+		}
+	})
+
+	t.Run("should pass record time to adapter", func(t *testing.T) {
+		adapter := &adapterMock{}
+		handler := sloghandler.New(adapter)
+		recordTime := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+		// when
+		err := handler.Handle(ctx, slog.NewRecord(recordTime, slog.LevelInfo, message, 0))
+		// then
+		require.NoError(t, err)
+		entry := adapter.HasExactlyOneEntry(t)
+		assert.Equal(t, recordTime, entry.Time)
+	})
+
+	t.Run("should pass record PC to adapter", func(t *testing.T) {
+		adapter := &adapterMock{}
+		handler := sloghandler.New(adapter)
+		const pc = 1234
+		// when
+		err := handler.Handle(ctx, slog.NewRecord(time.Now(), slog.LevelInfo, message, pc))
+		// then
+		require.NoError(t, err)
+		entry := adapter.HasExactlyOneEntry(t)
+		assert.Equal(t, uintptr(pc), entry.PC)
+	})
+
+	t.Run("should pass context to adapter", func(t *testing.T) {
+		type key struct{}
+
+		adapter := &adapterMock{}
+		log := slog.New(sloghandler.New(adapter))
+		ctxWithValue := context.WithValue(ctx, key{}, "value")
+		// when
+		log.InfoContext(ctxWithValue, message)
+		// then
+		require.Len(t, adapter.contexts, 1)
+		assert.Equal(t, "value", adapter.contexts[0].Value(key{}))
+	})
+
+	t.Run("should convert attributes into fields", func(t *testing.T) {
+		err := errors.New("error")
+
+		tests := map[string]struct {
+			attrs          []slog.Attr
+			expectedFields []logger.Field
+		}{
+			"string": {
+				attrs:          []slog.Attr{slog.String("k", "v")},
+				expectedFields: []logger.Field{logger.String("k", "v")},
+			},
+			"int": {
+				attrs:          []slog.Attr{slog.Int("k", 1)},
+				expectedFields: []logger.Field{logger.Int64("k", 1)},
+			},
+			"uint64": {
+				attrs:          []slog.Attr{slog.Uint64("k", 1)},
+				expectedFields: []logger.Field{logger.Uint64("k", 1)},
+			},
+			"float64": {
+				attrs:          []slog.Attr{slog.Float64("k", 1.1)},
+				expectedFields: []logger.Field{logger.Float64("k", 1.1)},
+			},
+			"bool": {
+				attrs:          []slog.Attr{slog.Bool("k", true)},
+				expectedFields: []logger.Field{logger.Bool("k", true)},
+			},
+			"time": {
+				attrs:          []slog.Attr{slog.Time("k", time.Unix(1000, 0))},
+				expectedFields: []logger.Field{logger.Time("k", time.Unix(1000, 0))},
+			},
+			"any": {
+				attrs:          []slog.Attr{slog.Any("k", []string{"v"})},
+				expectedFields: []logger.Field{logger.Any("k", []string{"v"})},
+			},
+			"duration": {
+				attrs:          []slog.Attr{slog.Duration("k", time.Second)},
+				expectedFields: []logger.Field{logger.Duration("k", time.Second)},
+			},
+			"error": {
+				attrs:          []slog.Attr{slog.Any("err", err)},
+				expectedFields: []logger.Field{logger.Err("err", err)},
+			},
+			"two attributes": {
+				attrs: []slog.Attr{slog.String("k1", "v1"), slog.String("k2", "v2")},
+				expectedFields: []logger.Field{
+					logger.String("k1", "v1"),
+					logger.String("k2", "v2"),
+				},
+			},
+			"group": {
+				attrs: []slog.Attr{slog.Group("g", slog.String("k1", "v1"), slog.String("k2", "v2"))},
+				expectedFields: []logger.Field{
+					logger.String("g.k1", "v1"),
+					logger.String("g.k2", "v2"),
+				},
+			},
+			"nested group": {
+				attrs:          []slog.Attr{slog.Group("g1", slog.Group("g2", slog.String("k", "v")))},
+				expectedFields: []logger.Field{logger.String("g1.g2.k", "v")},
+			},
+			"group without key": {
+				attrs:          []slog.Attr{slog.Group("", slog.String("k", "v"))},
+				expectedFields: []logger.Field{logger.String("k", "v")},
+			},
+			"empty group": {
+				attrs: []slog.Attr{slog.Group("g")},
+			},
+			"empty attribute": {
+				attrs: []slog.Attr{{}},
+			},
+			"log valuer": {
+				attrs:          []slog.Attr{slog.Any("k", logValuer{})},
+				expectedFields: []logger.Field{logger.String("k", "resolved")},
+			},
+		}
+
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				adapter := &adapterMock{}
+				log := slog.New(sloghandler.New(adapter))
+				// when
+				log.LogAttrs(ctx, slog.LevelInfo, message, test.attrs...)
+				// then
+				entry := adapter.HasExactlyOneEntry(t)
+				assert.Equal(t, test.expectedFields, entry.Fields)
+			})
+		}
+	})
+
+	t.Run("should log caller", func(t *testing.T) {
+		tests := map[string]func(log *slog.Logger){
+			"Info": func(log *slog.Logger) {
+				log.Info(message)
+			},
+			"InfoContext": func(log *slog.Logger) {
+				log.InfoContext(ctx, message)
+			},
+			"Log": func(log *slog.Logger) {
+				log.Log(ctx, slog.LevelInfo, message)
+			},
+			"LogAttrs": func(log *slog.Logger) {
+				log.LogAttrs(ctx, slog.LevelInfo, message)
+			},
+			"With": func(log *slog.Logger) {
+				log.With("k", "v").WithGroup("g").Info(message)
+			},
+		}
+
+		for name, logMessage := range tests {
+			t.Run(name, func(t *testing.T) {
+				adapter := &adapterMock{}
+				log := slog.New(sloghandler.New(adapter))
+				// when
+				logMessage(log)
+				// then
+				adapter.HasExactlyOneEntry(t)
+				require.Len(t, adapter.callers, 1)
+				const expectedPrefix = "github.com/elgopher/yala/adapter/sloghandler_test.TestHandler_Handle."
+				assert.Truef(t, strings.HasPrefix(adapter.callers[0], expectedPrefix),
+					"caller %s has no prefix %s", adapter.callers[0], expectedPrefix)
+			})
+		}
+	})
+}
+
+func TestHandler_Enabled(t *testing.T) {
+	t.Run("should return false when adapter is nil", func(t *testing.T) {
+		handler := sloghandler.New(nil)
+		assert.False(t, handler.Enabled(ctx, slog.LevelError))
+	})
+
+	t.Run("should return true when adapter does not implement logger.LevelEnabler", func(t *testing.T) {
+		handler := sloghandler.New(&adapterMock{})
+		assert.True(t, handler.Enabled(ctx, slog.LevelDebug))
+	})
+
+	t.Run("should use logger.LevelEnabler", func(t *testing.T) {
+		handler := sloghandler.New(fake.LevelEnablerAdapter{MinLevel: logger.WarnLevel})
+		assert.False(t, handler.Enabled(ctx, slog.LevelDebug))
+		assert.False(t, handler.Enabled(ctx, slog.LevelInfo))
+		assert.True(t, handler.Enabled(ctx, slog.LevelWarn))
+		assert.True(t, handler.Enabled(ctx, slog.LevelError))
+	})
+}
+
+func TestHandler_WithAttrs(t *testing.T) {
+	t.Run("should add fields", func(t *testing.T) {
+		adapter := &adapterMock{}
+		log := slog.New(sloghandler.New(adapter))
+		// when
+		log = log.With("k1", "v1")
+		// then
+		log.Info(message, "k2", "v2")
+		entry := adapter.HasExactlyOneEntry(t)
+		assert.Equal(t,
+			[]logger.Field{
+				logger.String("k1", "v1"),
+				logger.String("k2", "v2"),
+			},
+			entry.Fields)
+	})
+
+	t.Run("should not modify existing handler", func(t *testing.T) {
+		adapter := &adapterMock{}
+		log := slog.New(sloghandler.New(adapter)).With("k1", "v1")
+		// when
+		_ = log.With("k2", "v2")
+		// then
+		log.Info(message)
+		entry := adapter.HasExactlyOneEntry(t)
+		assert.Equal(t, []logger.Field{logger.String("k1", "v1")}, entry.Fields)
+	})
+}
+
+func TestHandler_WithGroup(t *testing.T) {
+	t.Run("should qualify subsequent attributes", func(t *testing.T) {
+		adapter := &adapterMock{}
+		log := slog.New(sloghandler.New(adapter))
+		// when
+		log = log.With("k1", "v1").WithGroup("g1").With("k2", "v2").WithGroup("g2")
+		// then
+		log.Info(message, "k3", "v3")
+		entry := adapter.HasExactlyOneEntry(t)
+		assert.Equal(t,
+			[]logger.Field{
+				logger.String("k1", "v1"),
+				logger.Group("g1"),
+				logger.String("k2", "v2"),
+				logger.Group("g2"),
+				logger.String("k3", "v3"),
+			},
+			entry.Fields)
+	})
+
+	t.Run("should not pass group without fields", func(t *testing.T) {
+		adapter := &adapterMock{}
+		log := slog.New(sloghandler.New(adapter))
+		// when
+		log = log.With("k", "v").WithGroup("g1").WithGroup("g2")
+		// then
+		log.Info(message)
+		entry := adapter.HasExactlyOneEntry(t)
+		assert.Equal(t, []logger.Field{logger.String("k", "v")}, entry.Fields)
+	})
+
+	t.Run("should not modify existing handler", func(t *testing.T) {
+		adapter := &adapterMock{}
+		log := slog.New(sloghandler.New(adapter)).WithGroup("g1")
+		// when
+		_ = log.WithGroup("g2")
+		// then
+		log.Info(message, "k", "v")
+		entry := adapter.HasExactlyOneEntry(t)
+		assert.Equal(t, []logger.Field{logger.Group("g1"), logger.String("k", "v")}, entry.Fields)
+	})
+
+	t.Run("should ignore empty group name", func(t *testing.T) {
+		adapter := &adapterMock{}
+		log := slog.New(sloghandler.New(adapter))
+		// when
+		log = log.WithGroup("")
+		// then
+		log.Info(message, "k", "v")
+		entry := adapter.HasExactlyOneEntry(t)
+		assert.Equal(t, []logger.Field{logger.String("k", "v")}, entry.Fields)
+	})
+}
+
+func TestHandler_Conformance(t *testing.T) {
+	adapter := &adapterMock{}
+	// when
+	err := slogtest.TestHandler(sloghandler.New(adapter), func() []map[string]interface{} {
+		results := make([]map[string]interface{}, len(adapter.entries))
+		for i, entry := range adapter.entries {
+			results[i] = entryToMap(entry)
+		}
+
+		return results
+	})
+	// then
+	assert.NoError(t, err)
+}
+
+// entryToMap converts the entry into a map expected by slogtest. Fields nested in groups, as well as fields with
+// dotted keys, are converted into nested maps.
+func entryToMap(entry logger.Entry) map[string]interface{} {
+	result := map[string]interface{}{
+		slog.LevelKey:   entry.Level.String(),
+		slog.MessageKey: entry.Message,
+	}
+
+	if !entry.Time.IsZero() {
+		result[slog.TimeKey] = entry.Time
+	}
+
+	group := result
+
+	for _, field := range entry.Fields {
+		if field.Kind() == logger.KindGroup {
+			nested := map[string]interface{}{}
+			group[field.Key] = nested
+			group = nested
+
+			continue
+		}
+
+		keys := strings.Split(field.Key, ".")
+		target := group
+
+		for _, key := range keys[:len(keys)-1] {
+			nested, ok := target[key].(map[string]interface{})
+			if !ok {
+				nested = map[string]interface{}{}
+				target[key] = nested
+			}
+
+			target = nested
+		}
+
+		target[keys[len(keys)-1]] = field.AnyValue()
+	}
+
+	return result
+}
+
+type adapterMock struct {
+	entries  []logger.Entry
+	contexts []context.Context
+	callers  []string // function names of callers
+}
+
+func (a *adapterMock) Log(ctx context.Context, entry logger.Entry) {
+	a.entries = append(a.entries, entry)
+	a.contexts = append(a.contexts, ctx)
+
+	if pc, _, _, ok := runtime.Caller(entry.SkippedCallerFrames + 1); ok {
+		a.callers = append(a.callers, runtime.FuncForPC(pc).Name())
+	}
+}
+
+func (a *adapterMock) HasExactlyOneEntry(t *testing.T) logger.Entry {
+	t.Helper()
+
+	require.Len(t, a.entries, 1)
+
+	return a.entries[0]
+}
+
+type logValuer struct{}
+
+func (logValuer) LogValue() slog.Value {
+	return slog.StringValue("resolved")
+}
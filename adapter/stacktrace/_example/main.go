@@ -0,0 +1,29 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	"github.com/elgopher/yala/adapter/console"
+	"github.com/elgopher/yala/adapter/stacktrace"
+	"github.com/elgopher/yala/logger"
+)
+
+var ErrSome = errors.New("ErrSome")
+
+// This example shows how to capture stack traces of error messages
+func main() {
+	ctx := context.Background()
+
+	// create middleware adapter which captures stack trace for entries with ErrorLevel,
+	// before reaching console adapter:
+	adapter := stacktrace.Adapter{
+		NextAdapter: console.StdoutAdapter(),
+		MinLevel:    logger.ErrorLevel,
+	}
+
+	log := logger.WithAdapter(adapter)
+
+	log.Info(ctx, "Message without stack trace")
+	log.ErrorCause(ctx, "Message with stack trace", ErrSome)
+}
@@ -0,0 +1,73 @@
+// (c) 2022 Jacek Olszak
+// This code is licensed under MIT license (see LICENSE for details)
+
+// Package stacktrace provides middleware adapter capturing stack traces of logged entries.
+package stacktrace
+
+import (
+	"context"
+	"runtime"
+
+	"github.com/elgopher/yala/logger"
+)
+
+// MaxDepth is the maximum number of frames captured.
+const MaxDepth = 64
+
+// Adapter is a middleware (decorator) adapter which captures a stack trace for each entry with level equal to or more
+// severe than MinLevel and stores it in logger.Entry Stack. The stack trace is then rendered by the next adapter.
+//
+// Please note that capturing stack trace is relatively expensive. Also, please make sure that Adapter is executed
+// in the same goroutine as the logger. Otherwise, the stack trace will not contain the function which logged
+// the entry.
+type Adapter struct {
+	NextAdapter logger.Adapter
+	// MinLevel is the least severe level for which stack trace is captured. Zero value is logger.InfoLevel, so
+	// please set it explicitly, for example to logger.ErrorLevel.
+	MinLevel logger.Level
+}
+
+// Log captures the stack trace and passes the entry to the next adapter.
+func (a Adapter) Log(ctx context.Context, entry logger.Entry) {
+	if a.NextAdapter == nil {
+		return
+	}
+
+	entry.SkippedCallerFrames++ // each middleware adapter must additionally skip one frame
+
+	if !a.MinLevel.MoreSevereThan(entry.Level) && entry.Stack == nil {
+		entry.Stack = captureStack(entry.SkippedCallerFrames)
+	}
+
+	a.NextAdapter.Log(ctx, entry)
+}
+
+func captureStack(skippedCallerFrames int) logger.Stack {
+	pcs := make([]uintptr, MaxDepth)
+
+	const skip = 2 // runtime.Callers and captureStack
+
+	n := runtime.Callers(skip+skippedCallerFrames, pcs)
+
+	return pcs[:n]
+}
+
+// Enabled passes the check to the next adapter. See logger.LevelEnabler.
+func (a Adapter) Enabled(ctx context.Context, level logger.Level) bool {
+	return a.NextAdapter != nil && logger.Enabled(ctx, a.NextAdapter, level)
+}
+
+// ReportsCaller passes the check to the next adapter. See logger.CallerReporter.
+func (a Adapter) ReportsCaller() bool {
+	return logger.ReportsCaller(a.NextAdapter)
+}
+
+// Sync passes the call to the next adapter. See logger.Syncer.
+func (a Adapter) Sync() error {
+	return logger.Sync(a.NextAdapter)
+}
+
+// Close passes the call to the next adapter. See logger.Closer.
+func (a Adapter) Close() error {
+	return logger.Close(a.NextAdapter)
+}
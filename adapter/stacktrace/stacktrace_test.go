@@ -0,0 +1,112 @@
+// (c) 2022 Jacek Olszak
+// This code is licensed under MIT license (see LICENSE for details)
+
+package stacktrace_test
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/elgopher/yala/adapter/internal/adaptertest"
+	"github.com/elgopher/yala/adapter/stacktrace"
+	"github.com/elgopher/yala/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const message = "message"
+
+var ctx = context.Background()
+
+func TestAdapter_Log(t *testing.T) {
+	t.Run("should not panic when next adapter is nil", func(t *testing.T) {
+		adapter := stacktrace.Adapter{}
+		assert.NotPanics(t, func() {
+			adapter.Log(ctx, logger.Entry{Level: logger.ErrorLevel, Message: message})
+		})
+	})
+
+	t.Run("should capture stack trace for entries with level equal to or more severe than MinLevel", func(t *testing.T) {
+		tests := map[string]func(logger.Logger){
+			"Warn": func(log logger.Logger) {
+				log.Warn(ctx, message)
+			},
+			"Error": func(log logger.Logger) {
+				log.Error(ctx, message)
+			},
+		}
+
+		for name, logMessage := range tests {
+			t.Run(name, func(t *testing.T) {
+				next := &adapterMock{}
+				log := logger.WithAdapter(stacktrace.Adapter{NextAdapter: next, MinLevel: logger.WarnLevel})
+				// when
+				logMessage(log)
+				// then
+				entry := next.HasExactlyOneEntry(t)
+				require.NotEmpty(t, entry.Stack)
+				frame, _ := runtime.CallersFrames(entry.Stack).Next()
+				const expectedPrefix = "github.com/elgopher/yala/adapter/stacktrace_test.TestAdapter_Log."
+				assert.Truef(t, strings.HasPrefix(frame.Function, expectedPrefix),
+					"function %s has no prefix %s", frame.Function, expectedPrefix)
+			})
+		}
+	})
+
+	t.Run("should not capture stack trace for entries with level less severe than MinLevel", func(t *testing.T) {
+		next := &adapterMock{}
+		log := logger.WithAdapter(stacktrace.Adapter{NextAdapter: next, MinLevel: logger.WarnLevel})
+		// when
+		log.Info(ctx, message)
+		// then
+		entry := next.HasExactlyOneEntry(t)
+		assert.Nil(t, entry.Stack)
+	})
+
+	t.Run("should not override stack trace captured before", func(t *testing.T) {
+		next := &adapterMock{}
+		adapter := stacktrace.Adapter{NextAdapter: next, MinLevel: logger.DebugLevel}
+		stack := logger.Stack{1, 2, 3}
+		// when
+		adapter.Log(ctx, logger.Entry{Level: logger.ErrorLevel, Message: message, Stack: stack})
+		// then
+		entry := next.HasExactlyOneEntry(t)
+		assert.Equal(t, stack, entry.Stack)
+	})
+
+	t.Run("should skip one more caller frame", func(t *testing.T) {
+		next := &adapterMock{}
+		adapter := stacktrace.Adapter{NextAdapter: next, MinLevel: logger.ErrorLevel}
+		// when
+		adapter.Log(ctx, logger.Entry{Level: logger.ErrorLevel, Message: message, SkippedCallerFrames: 1})
+		// then
+		entry := next.HasExactlyOneEntry(t)
+		assert.Equal(t, 2, entry.SkippedCallerFrames)
+	})
+}
+
+func TestAdapter_Middleware(t *testing.T) {
+	adaptertest.RunMiddleware(t, adaptertest.Middleware{
+		NewAdapter: func(next logger.Adapter) logger.Adapter {
+			return stacktrace.Adapter{NextAdapter: next}
+		},
+	})
+}
+
+type adapterMock struct {
+	entries []logger.Entry
+}
+
+func (a *adapterMock) Log(_ context.Context, entry logger.Entry) {
+	a.entries = append(a.entries, entry)
+}
+
+func (a *adapterMock) HasExactlyOneEntry(t *testing.T) logger.Entry {
+	t.Helper()
+
+	require.Len(t, a.entries, 1)
+
+	return a.entries[0]
+}
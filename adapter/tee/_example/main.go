@@ -0,0 +1,32 @@
+package main
+
+import (
+	"context"
+
+	"github.com/elgopher/yala/adapter/console"
+	"github.com/elgopher/yala/adapter/tee"
+	"github.com/elgopher/yala/logger"
+)
+
+// This example shows how to pass messages to multiple adapters
+func main() {
+	ctx := context.Background()
+
+	adapter := tee.Adapter{
+		Branches: []tee.Branch{
+			{
+				Adapter:  console.StdoutAdapter(),
+				MinLevel: logger.DebugLevel, // all messages are printed to stdout
+			},
+			{
+				Adapter:  console.StderrAdapter(),
+				MinLevel: logger.ErrorLevel, // only errors are printed to stderr
+			},
+		},
+	}
+
+	log := logger.WithAdapter(adapter)
+
+	log.Debug(ctx, "Debug message printed to stdout")
+	log.Error(ctx, "Error message printed to stdout and stderr")
+}
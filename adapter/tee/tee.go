@@ -0,0 +1,119 @@
+// (c) 2022 Jacek Olszak
+// This code is licensed under MIT license (see LICENSE for details)
+
+// Package tee provides adapter passing entries to multiple adapters.
+package tee
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/elgopher/yala/logger"
+)
+
+// Adapter is a logger.Adapter which passes each entry to all Branches, for example to console adapter and
+// JSON file adapter.
+//
+// Branches are executed sequentially, in the same goroutine. Panic in one branch does not stop others.
+type Adapter struct {
+	Branches []Branch
+	// OnPanic is an optional function called when a branch panics. By default, the panic is printed to stderr.
+	OnPanic func(branch Branch, recovered interface{})
+}
+
+// Branch is a single adapter used by tee Adapter.
+type Branch struct {
+	Adapter logger.Adapter
+	// MinLevel is the least severe level passed to the Adapter. Zero value is logger.InfoLevel, so please set it
+	// explicitly, for example to logger.DebugLevel.
+	MinLevel logger.Level
+	// Filter is an optional function deciding whether the entry should be passed to the Adapter.
+	Filter func(ctx context.Context, entry logger.Entry) bool
+}
+
+func (b Branch) accepts(ctx context.Context, entry logger.Entry) bool {
+	if b.Adapter == nil || b.MinLevel.MoreSevereThan(entry.Level) {
+		return false
+	}
+
+	return b.Filter == nil || b.Filter(ctx, entry)
+}
+
+// Log passes the entry to all branches accepting it.
+func (a Adapter) Log(ctx context.Context, entry logger.Entry) {
+	// each middleware adapter must additionally skip one frame, and logBranch adds another one
+	entry.SkippedCallerFrames += 2
+
+	for _, branch := range a.Branches {
+		if branch.accepts(ctx, entry) {
+			a.logBranch(ctx, branch, entry)
+		}
+	}
+}
+
+func (a Adapter) logBranch(ctx context.Context, branch Branch, entry logger.Entry) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			a.reportPanic(branch, recovered)
+		}
+	}()
+
+	branch.Adapter.Log(ctx, entry)
+}
+
+func (a Adapter) reportPanic(branch Branch, recovered interface{}) {
+	if a.OnPanic != nil {
+		a.OnPanic(branch, recovered)
+
+		return
+	}
+
+	_, _ = fmt.Fprintf(os.Stderr, "tee: adapter %T panicked: %v\n", branch.Adapter, recovered)
+}
+
+// Enabled returns true if at least one branch is enabled for the level. See logger.LevelEnabler.
+func (a Adapter) Enabled(ctx context.Context, level logger.Level) bool {
+	for _, branch := range a.Branches {
+		if branch.Adapter != nil && !branch.MinLevel.MoreSevereThan(level) &&
+			logger.Enabled(ctx, branch.Adapter, level) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ReportsCaller returns true if any branch reports caller. See logger.CallerReporter.
+func (a Adapter) ReportsCaller() bool {
+	for _, branch := range a.Branches {
+		if logger.ReportsCaller(branch.Adapter) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Sync passes the call to all branches. See logger.Syncer.
+func (a Adapter) Sync() error {
+	var errs []error
+
+	for _, branch := range a.Branches {
+		errs = append(errs, logger.Sync(branch.Adapter))
+	}
+
+	return errors.Join(errs...)
+}
+
+// Close passes the call to all branches. See logger.Closer.
+func (a Adapter) Close() error {
+	var errs []error
+
+	for _, branch := range a.Branches {
+		errs = append(errs, logger.Close(branch.Adapter))
+	}
+
+	return errors.Join(errs...)
+}
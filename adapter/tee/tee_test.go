@@ -0,0 +1,205 @@
+// (c) 2022 Jacek Olszak
+// This code is licensed under MIT license (see LICENSE for details)
+
+package tee_test
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"testing"
+
+	"github.com/elgopher/yala/adapter/internal/fake"
+	"github.com/elgopher/yala/adapter/tee"
+	"github.com/elgopher/yala/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const message = "message"
+
+var ctx = context.Background()
+
+func TestAdapter_Log(t *testing.T) {
+	t.Run("should not panic when branch adapter is nil", func(t *testing.T) {
+		adapter := tee.Adapter{Branches: []tee.Branch{{}}}
+		assert.NotPanics(t, func() {
+			adapter.Log(ctx, logger.Entry{Level: logger.InfoLevel, Message: message})
+		})
+	})
+
+	t.Run("should pass entry to all branches", func(t *testing.T) {
+		branch1, branch2 := &fake.Adapter{}, &fake.Adapter{}
+		adapter := tee.Adapter{Branches: []tee.Branch{{Adapter: branch1}, {Adapter: branch2}}}
+		entry := logger.Entry{Level: logger.InfoLevel, Message: message}
+		// when
+		adapter.Log(ctx, entry)
+		// then
+		assert.Len(t, branch1.Entries(), 1)
+		assert.Len(t, branch2.Entries(), 1)
+	})
+
+	t.Run("should pass entry only to branches with less or equally severe MinLevel", func(t *testing.T) {
+		debugBranch, errorBranch := &fake.Adapter{}, &fake.Adapter{}
+		adapter := tee.Adapter{
+			Branches: []tee.Branch{
+				{Adapter: debugBranch, MinLevel: logger.DebugLevel},
+				{Adapter: errorBranch, MinLevel: logger.ErrorLevel},
+			},
+		}
+		// when
+		adapter.Log(ctx, logger.Entry{Level: logger.DebugLevel, Message: "debug"})
+		adapter.Log(ctx, logger.Entry{Level: logger.ErrorLevel, Message: "error"})
+		// then
+		assert.Len(t, debugBranch.Entries(), 2)
+		errorEntries := errorBranch.Entries()
+		require.Len(t, errorEntries, 1)
+		assert.Equal(t, "error", errorEntries[0].Message)
+	})
+
+	t.Run("should pass entry only to branches accepting it using Filter", func(t *testing.T) {
+		branch := &fake.Adapter{}
+		adapter := tee.Adapter{
+			Branches: []tee.Branch{
+				{
+					Adapter: branch,
+					Filter: func(ctx context.Context, entry logger.Entry) bool {
+						return entry.Message == "accepted"
+					},
+				},
+			},
+		}
+		// when
+		adapter.Log(ctx, logger.Entry{Level: logger.InfoLevel, Message: "accepted"})
+		adapter.Log(ctx, logger.Entry{Level: logger.InfoLevel, Message: "rejected"})
+		// then
+		entries := branch.Entries()
+		require.Len(t, entries, 1)
+		assert.Equal(t, "accepted", entries[0].Message)
+	})
+
+	t.Run("should pass entry to next branches when branch panics", func(t *testing.T) {
+		next := &fake.Adapter{}
+		var recoveredPanic interface{}
+		panickingBranch := tee.Branch{Adapter: panickingAdapter{}}
+		adapter := tee.Adapter{
+			Branches: []tee.Branch{panickingBranch, {Adapter: next}},
+			OnPanic: func(branch tee.Branch, recovered interface{}) {
+				assert.Equal(t, panickingBranch.Adapter, branch.Adapter)
+				recoveredPanic = recovered
+			},
+		}
+		// when
+		adapter.Log(ctx, logger.Entry{Level: logger.InfoLevel, Message: message})
+		// then
+		assert.Equal(t, "panic", recoveredPanic)
+		assert.Len(t, next.Entries(), 1)
+	})
+
+	t.Run("should print panic to stderr when OnPanic is nil", func(t *testing.T) {
+		stderr := fake.UseFakeStderr(t)
+		defer stderr.Release()
+
+		adapter := tee.Adapter{Branches: []tee.Branch{{Adapter: panickingAdapter{}}}}
+		// when
+		adapter.Log(ctx, logger.Entry{Level: logger.InfoLevel, Message: message})
+		// then
+		assert.Equal(t, "tee: adapter tee_test.panickingAdapter panicked: panic\n", stderr.String(t))
+	})
+
+	t.Run("should report caller in each branch", func(t *testing.T) {
+		branch1, branch2 := &callerAdapterMock{}, &callerAdapterMock{}
+		adapter := tee.Adapter{Branches: []tee.Branch{{Adapter: branch1}, {Adapter: branch2}}}
+		log := logger.WithAdapter(adapter)
+		// when
+		_, _, line, _ := runtime.Caller(0)
+		log.Info(ctx, message)
+		// then
+		assert.Equal(t, []int{line + 1}, branch1.lines)
+		assert.Equal(t, []int{line + 1}, branch2.lines)
+	})
+}
+
+func TestAdapter_Enabled(t *testing.T) {
+	t.Run("should return false when there are no branches", func(t *testing.T) {
+		adapter := tee.Adapter{}
+		assert.False(t, adapter.Enabled(ctx, logger.ErrorLevel))
+	})
+
+	t.Run("should return true when at least one branch is enabled", func(t *testing.T) {
+		adapter := tee.Adapter{
+			Branches: []tee.Branch{
+				{Adapter: fake.LevelEnablerAdapter{MinLevel: logger.WarnLevel}, MinLevel: logger.DebugLevel},
+				{Adapter: &fake.Adapter{}, MinLevel: logger.ErrorLevel},
+			},
+		}
+		assert.False(t, adapter.Enabled(ctx, logger.DebugLevel))
+		assert.False(t, adapter.Enabled(ctx, logger.InfoLevel))
+		assert.True(t, adapter.Enabled(ctx, logger.WarnLevel))
+		assert.True(t, adapter.Enabled(ctx, logger.ErrorLevel))
+	})
+}
+
+func TestAdapter_ReportsCaller(t *testing.T) {
+	t.Run("should return true when any branch reports caller", func(t *testing.T) {
+		adapter := tee.Adapter{Branches: []tee.Branch{
+			{Adapter: &fake.Adapter{}},
+			{Adapter: &fake.Adapter{CallerReported: true}},
+		}}
+		assert.True(t, adapter.ReportsCaller())
+	})
+
+	t.Run("should return false when no branch reports caller", func(t *testing.T) {
+		adapter := tee.Adapter{Branches: []tee.Branch{{Adapter: &fake.Adapter{}}}}
+		assert.False(t, adapter.ReportsCaller())
+	})
+}
+
+func TestAdapter_Sync(t *testing.T) {
+	t.Run("should sync all branches", func(t *testing.T) {
+		err1, err2 := errors.New("1"), errors.New("2")
+		branch1, branch2 := &fake.Adapter{Err: err1}, &fake.Adapter{Err: err2}
+		adapter := tee.Adapter{Branches: []tee.Branch{{Adapter: branch1}, {Adapter: &adapterMock{}}, {Adapter: branch2}}}
+		// when
+		err := adapter.Sync()
+		// then
+		assert.ErrorIs(t, err, err1)
+		assert.ErrorIs(t, err, err2)
+		assert.Equal(t, 1, branch1.SyncCalls())
+		assert.Equal(t, 1, branch2.SyncCalls())
+	})
+}
+
+func TestAdapter_Close(t *testing.T) {
+	t.Run("should close all branches", func(t *testing.T) {
+		err1, err2 := errors.New("1"), errors.New("2")
+		branch1, branch2 := &fake.Adapter{Err: err1}, &fake.Adapter{Err: err2}
+		adapter := tee.Adapter{Branches: []tee.Branch{{Adapter: branch1}, {Adapter: &adapterMock{}}, {Adapter: branch2}}}
+		// when
+		err := adapter.Close()
+		// then
+		assert.ErrorIs(t, err, err1)
+		assert.ErrorIs(t, err, err2)
+		assert.Equal(t, 1, branch1.CloseCalls())
+		assert.Equal(t, 1, branch2.CloseCalls())
+	})
+}
+
+type adapterMock struct{}
+
+func (a *adapterMock) Log(context.Context, logger.Entry) {}
+
+type panickingAdapter struct{}
+
+func (p panickingAdapter) Log(context.Context, logger.Entry) {
+	panic("panic")
+}
+
+type callerAdapterMock struct {
+	lines []int
+}
+
+func (a *callerAdapterMock) Log(_ context.Context, entry logger.Entry) {
+	_, _, line, _ := runtime.Caller(entry.SkippedCallerFrames + 1)
+	a.lines = append(a.lines, line)
+}
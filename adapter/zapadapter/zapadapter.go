@@ -9,6 +9,7 @@ import (
 
 	"github.com/elgopher/yala/logger"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
 // Adapter is a logger.Adapter implementation, which is using `zap` module (https://github.com/uber-go/zap).
@@ -16,44 +17,165 @@ type Adapter struct {
 	Logger *zap.Logger
 }
 
-// Log logs the entry using zap module.
+// Log logs the entry using zap module. Time, name, caller and stack trace of the entry are used as zap entry time,
+// logger name, caller and stack. Error is logged with its concrete type ("errorType") and errors joined in it ("errorCauses").
 func (a Adapter) Log(_ context.Context, entry logger.Entry) {
 	if a.Logger == nil {
 		return
 	}
 
-	zapLogger := a.Logger.WithOptions(zap.AddCallerSkip(entry.SkippedCallerFrames + 1))
-	fields := zapFields(entry)
+	zapLogger := a.Logger
+	if entry.PC == 0 { // caller is not known, so it has to be found by zap
+		zapLogger = zapLogger.WithOptions(zap.AddCallerSkip(entry.SkippedCallerFrames + 1))
+	}
+
+	checkedEntry := zapLogger.Check(zapLevel(entry.Level), entry.Message)
+	if checkedEntry == nil {
+		return
+	}
+
+	if !entry.Time.IsZero() {
+		checkedEntry.Time = entry.Time
+	}
+
+	if entry.Name != "" {
+		checkedEntry.LoggerName = joinNames(checkedEntry.LoggerName, entry.Name)
+	}
+
+	// caller is reported only if zap logger is configured to do so
+	if frame, ok := entry.Caller(); ok && checkedEntry.Caller.Defined {
+		checkedEntry.Caller = zapcore.EntryCaller{
+			Defined:  true,
+			PC:       frame.PC,
+			File:     frame.File,
+			Line:     frame.Line,
+			Function: frame.Function,
+		}
+	}
+
+	if len(entry.Stack) > 0 {
+		checkedEntry.Stack = entry.Stack.String()
+	}
+
+	checkedEntry.Write(zapFields(entry)...)
+}
+
+// joinNames joins the name of zap logger (see zap.Logger Named) with the name of the entry.
+func joinNames(zapLoggerName, entryName string) string {
+	if zapLoggerName == "" {
+		return entryName
+	}
+
+	return zapLoggerName + "." + entryName
+}
+
+// Enabled returns true if zap logger is configured to log messages with given level.
+func (a Adapter) Enabled(_ context.Context, level logger.Level) bool {
+	if a.Logger == nil {
+		return false
+	}
+
+	return a.Logger.Core().Enabled(zapLevel(level))
+}
 
-	switch entry.Level {
+// ReportsCaller returns true, because Entry.PC is used to log the caller, when zap logger is configured to do so.
+// See logger.CallerReporter.
+func (a Adapter) ReportsCaller() bool {
+	return a.Logger != nil
+}
+
+// Sync flushes entries buffered by zap logger. See logger.Syncer.
+func (a Adapter) Sync() error {
+	if a.Logger == nil {
+		return nil
+	}
+
+	return a.Logger.Sync()
+}
+
+func zapLevel(level logger.Level) zapcore.Level {
+	switch level {
 	case logger.DebugLevel:
-		zapLogger.Debug(entry.Message, fields...)
+		return zapcore.DebugLevel
 	case logger.InfoLevel:
-		zapLogger.Info(entry.Message, fields...)
+		return zapcore.InfoLevel
 	case logger.WarnLevel:
-		zapLogger.Warn(entry.Message, fields...)
+		return zapcore.WarnLevel
 	case logger.ErrorLevel:
-		zapLogger.Error(entry.Message, fields...)
+		return zapcore.ErrorLevel
 	default:
-		zapLogger.Info(entry.Message, fields...)
+		return zapcore.InfoLevel
 	}
 }
 
 func zapFields(entry logger.Entry) []zap.Field {
+	const lengthOfError = 3 // error, errorType and errorCauses
+
 	length := len(entry.Fields)
 	if entry.Error != nil {
-		length++
+		length += lengthOfError
 	}
 
-	fields := make([]zap.Field, length)
+	fields := make([]zap.Field, 0, length)
 
-	for i, f := range entry.Fields {
-		fields[i] = zap.Any(f.Key, f.Value)
+	// error goes first, because fields might open a namespace
+	if entry.Error != nil {
+		fields = appendErrorFields(fields, entry.Error)
 	}
 
-	if entry.Error != nil {
-		fields[length-1] = zap.Error(entry.Error)
+	for _, f := range entry.Fields {
+		fields = append(fields, zapField(f))
 	}
 
 	return fields
 }
+
+// appendErrorFields appends the error, the type of its root cause ("errorType"), joined errors ("errorCauses")
+// and their types ("errorCauseTypes"). Causes of errors implementing Errors() []error method are already logged
+// by zap.Error.
+func appendErrorFields(fields []zap.Field, err error) []zap.Field {
+	fields = append(fields, zap.Error(err), zap.String("errorType", logger.ErrorType(err)))
+
+	causes := logger.ErrorCauses(err)
+	if len(causes) == 0 {
+		return fields
+	}
+
+	if _, ok := err.(interface{ Errors() []error }); !ok { //nolint:errorlint // the same check is done by zap
+		fields = append(fields, zap.Errors("errorCauses", causes))
+	}
+
+	types := make([]string, len(causes))
+	for i, cause := range causes {
+		types[i] = logger.ErrorType(cause)
+	}
+
+	return append(fields, zap.Strings("errorCauseTypes", types))
+}
+
+func zapField(field logger.Field) zap.Field {
+	switch field.Kind() {
+	case logger.KindString:
+		return zap.String(field.Key, field.StringValue())
+	case logger.KindInt64:
+		return zap.Int64(field.Key, field.Int64Value())
+	case logger.KindUint64:
+		return zap.Uint64(field.Key, field.Uint64Value())
+	case logger.KindFloat64:
+		return zap.Float64(field.Key, field.Float64Value())
+	case logger.KindBool:
+		return zap.Bool(field.Key, field.BoolValue())
+	case logger.KindDuration:
+		return zap.Duration(field.Key, field.DurationValue())
+	case logger.KindTime:
+		return zap.Time(field.Key, field.TimeValue())
+	case logger.KindError:
+		return zap.NamedError(field.Key, field.ErrorValue())
+	case logger.KindGroup:
+		return zap.Namespace(field.Key)
+	case logger.KindAny:
+		return zap.Any(field.Key, field.Value)
+	default:
+		return zap.Any(field.Key, field.AnyValue())
+	}
+}
@@ -6,9 +6,12 @@ package zapadapter_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/url"
+	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
 	"time"
@@ -20,6 +23,8 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 const message = "message"
@@ -51,12 +56,243 @@ func TestAdapter_Log(t *testing.T) {
 		assert.Truef(t, strings.HasPrefix(msg.C, expectedPrefix), "caller %s has no prefix %s", msg.C, expectedPrefix)
 	})
 
+	t.Run("should log caller from entry PC", func(t *testing.T) {
+		var builder strings.Builder
+		adapter := newAdapter(&builder)
+		var pcs [1]uintptr
+		runtime.Callers(1, pcs[:])
+		_, _, line, _ := runtime.Caller(0)
+		// when
+		adapter.Log(ctx, logger.Entry{
+			Level:               logger.InfoLevel,
+			Message:             message,
+			PC:                  pcs[0],
+			SkippedCallerFrames: 100, // should be ignored
+		})
+		// then
+		msg := unmarshalZapMessage(t, builder.String())
+		expectedCaller := fmt.Sprintf("zapadapter/zapadapter_test.go:%d", line-1)
+		assert.Equal(t, expectedCaller, msg.C)
+	})
+
+	t.Run("should log caller captured by logger", func(t *testing.T) {
+		core, logs := observer.New(zapcore.DebugLevel)
+		adapter := zapadapter.Adapter{Logger: zap.New(core, zap.AddCaller())}
+		log := logger.WithAdapter(middlewareNotSkippingFrames{next: adapter})
+		// when
+		log.Info(ctx, message)
+		_, _, line, _ := runtime.Caller(0)
+		// then
+		entries := logs.All()
+		require.Len(t, entries, 1)
+		caller := entries[0].Caller
+		assert.NotZero(t, caller.PC)
+		assert.Equal(t, "zapadapter_test.go", filepath.Base(caller.File))
+		assert.Equal(t, line-1, caller.Line)
+	})
+
+	t.Run("should log fields nested in groups", func(t *testing.T) {
+		var builder strings.Builder
+		adapter := newAdapter(&builder)
+		entry := logger.Entry{
+			Level:   logger.ErrorLevel,
+			Message: message,
+			Fields: []logger.Field{
+				logger.String("k1", "v1"),
+				logger.Group("http"),
+				logger.Int("status", 200),
+				logger.Group("db"),
+				logger.String("k2", "v2"),
+			},
+			Error: errors.New("err"),
+		}
+		// when
+		adapter.Log(ctx, entry)
+		// then
+		var out map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(builder.String()), &out))
+		assert.Equal(t, "v1", out["k1"])
+		assert.Equal(t, "err", out["error"])
+		expectedGroup := map[string]interface{}{
+			"status": 200.0,
+			"db": map[string]interface{}{
+				"k2": "v2",
+			},
+		}
+		assert.Equal(t, expectedGroup, out["http"])
+	})
+
+	t.Run("should log joined errors with type and causes with their types", func(t *testing.T) {
+		var builder strings.Builder
+		adapter := newAdapter(&builder)
+		// when
+		adapter.Log(ctx, logger.Entry{
+			Level:   logger.ErrorLevel,
+			Message: message,
+			Error:   errors.Join(errors.New("a"), errors.New("b")),
+		})
+		// then
+		var out map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(builder.String()), &out))
+		assert.Equal(t, "a\nb", out["error"])
+		assert.Equal(t, "*errors.joinError", out["errorType"])
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"error": "a"},
+			map[string]interface{}{"error": "b"},
+		}, out["errorCauses"])
+		assert.Equal(t, []interface{}{"*errors.errorString", "*errors.errorString"}, out["errorCauseTypes"])
+	})
+
+	t.Run("should log entry name as logger name", func(t *testing.T) {
+		tests := map[string]struct {
+			zapLoggerName string
+			expectedName  string
+		}{
+			"zap logger without name": {
+				expectedName: "payments.db",
+			},
+			"zap logger with name": {
+				zapLoggerName: "app",
+				expectedName:  "app.payments.db",
+			},
+		}
+
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				var builder strings.Builder
+				encoder := zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig())
+				zapLogger := zap.New(zapcore.NewCore(encoder, zapcore.AddSync(&builder), zapcore.InfoLevel))
+				adapter := zapadapter.Adapter{Logger: zapLogger.Named(test.zapLoggerName)}
+				// when
+				adapter.Log(ctx, logger.Entry{Level: logger.InfoLevel, Message: message, Name: "payments.db"})
+				// then
+				var out map[string]interface{}
+				require.NoError(t, json.Unmarshal([]byte(builder.String()), &out))
+				assert.Equal(t, test.expectedName, out["logger"])
+			})
+		}
+	})
+
+	t.Run("should log entry stack trace", func(t *testing.T) {
+		var builder strings.Builder
+		adapter := newAdapter(&builder)
+		var pcs [2]uintptr
+		runtime.Callers(1, pcs[:])
+		stack := logger.Stack(pcs[:])
+		// when
+		adapter.Log(ctx, logger.Entry{
+			Level:   logger.InfoLevel,
+			Message: message,
+			Stack:   stack,
+		})
+		// then
+		msg := unmarshalZapMessage(t, builder.String())
+		assert.Equal(t, stack.String(), msg.S)
+	})
+
+	t.Run("should log entry time", func(t *testing.T) {
+		var builder strings.Builder
+		adapter := newAdapter(&builder)
+		entryTime := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+		// when
+		adapter.Log(ctx, logger.Entry{
+			Level:   logger.InfoLevel,
+			Message: message,
+			Time:    entryTime,
+		})
+		// then
+		msg := unmarshalZapMessage(t, builder.String())
+		actualTime, err := time.Parse("2006-01-02T15:04:05.000Z0700", msg.T)
+		require.NoError(t, err)
+		assert.True(t, entryTime.Equal(actualTime), "expected %s, got %s", entryTime, actualTime)
+	})
+
 	adaptertest.Run(t, adaptertest.Subject{
 		NewAdapter:       newAdapter,
 		UnmarshalMessage: unmarshalMessage,
 	})
 }
 
+func TestAdapter_Enabled(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should return false when logger is nil", func(t *testing.T) {
+		adapter := zapadapter.Adapter{Logger: nil}
+		assert.False(t, adapter.Enabled(ctx, logger.ErrorLevel))
+	})
+
+	t.Run("should use zap logger level", func(t *testing.T) {
+		core := zapcore.NewCore(
+			zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()),
+			zapcore.AddSync(io.Discard),
+			zapcore.WarnLevel,
+		)
+		adapter := zapadapter.Adapter{Logger: zap.New(core)}
+		assert.False(t, adapter.Enabled(ctx, logger.DebugLevel))
+		assert.False(t, adapter.Enabled(ctx, logger.InfoLevel))
+		assert.True(t, adapter.Enabled(ctx, logger.WarnLevel))
+		assert.True(t, adapter.Enabled(ctx, logger.ErrorLevel))
+	})
+}
+
+func TestAdapter_ReportsCaller(t *testing.T) {
+	t.Run("should return false when logger is nil", func(t *testing.T) {
+		adapter := zapadapter.Adapter{Logger: nil}
+		assert.False(t, adapter.ReportsCaller())
+	})
+
+	t.Run("should return true", func(t *testing.T) {
+		adapter := zapadapter.Adapter{Logger: zap.NewNop()}
+		assert.True(t, adapter.ReportsCaller())
+	})
+}
+
+// middlewareNotSkippingFrames passes entries to the next adapter without incrementing SkippedCallerFrames, so the next
+// adapter has to use Entry.PC to log the caller.
+type middlewareNotSkippingFrames struct {
+	next logger.Adapter
+}
+
+func (m middlewareNotSkippingFrames) Log(ctx context.Context, entry logger.Entry) {
+	m.next.Log(ctx, entry)
+}
+
+func (m middlewareNotSkippingFrames) ReportsCaller() bool {
+	return logger.ReportsCaller(m.next)
+}
+
+func TestAdapter_Sync(t *testing.T) {
+	t.Run("should return nil when logger is nil", func(t *testing.T) {
+		adapter := zapadapter.Adapter{Logger: nil}
+		assert.NoError(t, adapter.Sync())
+	})
+
+	t.Run("should sync zap logger", func(t *testing.T) {
+		syncer := &writeSyncerMock{}
+		core := zapcore.NewCore(zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), syncer, zapcore.InfoLevel)
+		adapter := zapadapter.Adapter{Logger: zap.New(core)}
+		// when
+		err := adapter.Sync()
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 1, syncer.syncCalls)
+	})
+}
+
+type writeSyncerMock struct {
+	syncCalls int
+}
+
+func (w *writeSyncerMock) Write(p []byte) (int, error) {
+	return len(p), nil
+}
+
+func (w *writeSyncerMock) Sync() error {
+	w.syncCalls++
+
+	return nil
+}
+
 func newAdapter(writer io.Writer) logger.Adapter {
 	scheme := generateUniqueScheme() // Zap does not allow to override existing scheme
 	_ = zap.RegisterSink(scheme, func(url *url.URL) (zap.Sink, error) {
@@ -113,7 +349,9 @@ func unmarshalMessage(t *testing.T, line string) adaptertest.Message {
 		IntField:       out.IntField,
 		Int64Field:     out.Int64Field,
 		Float32Field:   out.Float32Field,
+		Uint64Field:    out.Uint64Field,
 		Float64Field:   out.Float64Field,
+		BoolField:      out.BoolField,
 		TimeField:      out.TimeField,
 		InterfaceField: out.InterfaceField,
 	}
@@ -130,15 +368,19 @@ func unmarshalZapMessage(t *testing.T, line string) zapMessage {
 }
 
 type zapMessage struct {
+	T              string // time
 	L              string // level
 	M              string // message
 	C              string // caller
+	S              string // stack trace
 	Error          string
 	StringField    string
 	IntField       int
 	Int64Field     int64
 	Float32Field   float32
+	Uint64Field    uint64
 	Float64Field   float64
+	BoolField      bool
 	TimeField      time.Time
 	InterfaceField adaptertest.InterfaceField
 }
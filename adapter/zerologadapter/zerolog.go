@@ -15,23 +15,68 @@ import (
 // Adapter is a logger.Adapter implementation, which is using `zerolog` module (https://github.com/rs/zerolog).
 type Adapter struct {
 	Logger zerolog.Logger
+	// Timestamp adds the time of the entry to each message, using zerolog.TimestampFieldName as a key. Please use it
+	// instead of zerolog.Context.Timestamp, which adds the time when the message is written, not when it was logged.
+	Timestamp bool
 }
 
-// Log logs the entry using zerolog module.
+// Log logs the entry using zerolog module. Error is logged with its concrete type and errors joined in it.
 func (l Adapter) Log(ctx context.Context, entry logger.Entry) {
 	event := l.Logger.WithLevel(convertLevel(entry.Level))
 
-	for _, field := range entry.Fields {
-		event = eventWithField(event, field)
+	if l.Timestamp {
+		event = eventWithTimestamp(event, entry.Time)
+	}
+
+	if entry.Name != "" {
+		event = event.Str(logger.NameKey, entry.Name)
 	}
 
+	event = eventWithFields(event, entry.Fields)
+
 	if entry.Error != nil {
-		event = event.Err(entry.Error)
+		event = eventWithError(event, entry.Error)
+	}
+
+	if len(entry.Stack) > 0 {
+		event = event.Str(zerolog.ErrorStackFieldName, entry.Stack.String())
 	}
 
 	event.Msg(entry.Message)
 }
 
+// Enabled returns true if zerolog logger (and global level) is configured to log messages with given level.
+func (l Adapter) Enabled(_ context.Context, level logger.Level) bool {
+	zerologLevel := convertLevel(level)
+
+	return zerologLevel >= l.Logger.GetLevel() && zerologLevel >= zerolog.GlobalLevel()
+}
+
+// eventWithError adds the error, the type of its root cause, and errors joined in it together with their types. Keys
+// are prefixed with zerolog.ErrorFieldName, for example "errorType", "errorCauses" and "errorCauseTypes".
+func eventWithError(event *zerolog.Event, err error) *zerolog.Event {
+	event = event.Err(err).Str(zerolog.ErrorFieldName+"Type", logger.ErrorType(err))
+
+	if causes := logger.ErrorCauses(err); len(causes) > 0 {
+		types := make([]string, len(causes))
+		for i, cause := range causes {
+			types[i] = logger.ErrorType(cause)
+		}
+
+		event = event.Errs(zerolog.ErrorFieldName+"Causes", causes).Strs(zerolog.ErrorFieldName+"CauseTypes", types)
+	}
+
+	return event
+}
+
+func eventWithTimestamp(event *zerolog.Event, entryTime time.Time) *zerolog.Event {
+	if entryTime.IsZero() {
+		return event.Timestamp()
+	}
+
+	return event.Time(zerolog.TimestampFieldName, entryTime)
+}
+
 func convertLevel(level logger.Level) zerolog.Level {
 	switch level {
 	case logger.DebugLevel:
@@ -47,22 +92,60 @@ func convertLevel(level logger.Level) zerolog.Level {
 	}
 }
 
+// eventWithFields adds fields to the event. Fields nested in a group are added as a nested dictionary.
+func eventWithFields(event *zerolog.Event, fields []logger.Field) *zerolog.Event {
+	for i, field := range fields {
+		if field.Kind() == logger.KindGroup {
+			return event.Dict(field.Key, eventWithFields(zerolog.Dict(), fields[i+1:]))
+		}
+
+		event = eventWithField(event, field)
+	}
+
+	return event
+}
+
 func eventWithField(event *zerolog.Event, field logger.Field) *zerolog.Event {
-	switch value := field.Value.(type) {
+	switch field.Kind() {
+	case logger.KindString:
+		return event.Str(field.Key, field.StringValue())
+	case logger.KindInt64:
+		return event.Int64(field.Key, field.Int64Value())
+	case logger.KindUint64:
+		return event.Uint64(field.Key, field.Uint64Value())
+	case logger.KindFloat64:
+		return event.Float64(field.Key, field.Float64Value())
+	case logger.KindBool:
+		return event.Bool(field.Key, field.BoolValue())
+	case logger.KindDuration:
+		return event.Dur(field.Key, field.DurationValue())
+	case logger.KindTime:
+		return event.Time(field.Key, field.TimeValue())
+	case logger.KindError:
+		return event.AnErr(field.Key, field.ErrorValue())
+	case logger.KindAny:
+		return eventWithAnyField(event, field.Key, field.Value)
+	default:
+		return eventWithAnyField(event, field.Key, field.AnyValue())
+	}
+}
+
+func eventWithAnyField(event *zerolog.Event, key string, value interface{}) *zerolog.Event {
+	switch value := value.(type) {
 	case string:
-		event = event.Str(field.Key, value)
+		event = event.Str(key, value)
 	case int:
-		event = event.Int(field.Key, value)
+		event = event.Int(key, value)
 	case int64:
-		event = event.Int64(field.Key, value)
+		event = event.Int64(key, value)
 	case float64:
-		event = event.Float64(field.Key, value)
+		event = event.Float64(key, value)
 	case float32:
-		event = event.Float32(field.Key, value)
+		event = event.Float32(key, value)
 	case time.Time:
-		event = event.Time(field.Key, value)
+		event = event.Time(key, value)
 	default:
-		event = event.Interface(field.Key, field.Value)
+		event = event.Interface(key, value)
 	}
 
 	return event
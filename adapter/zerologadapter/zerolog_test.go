@@ -6,7 +6,10 @@ package zerologadapter_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
+	"runtime"
+	"strings"
 	"testing"
 	"time"
 
@@ -33,6 +36,119 @@ func TestAdapter_Log(t *testing.T) {
 		})
 	})
 
+	t.Run("should log fields nested in groups", func(t *testing.T) {
+		var builder strings.Builder
+		adapter := zerologadapter.Adapter{Logger: zerolog.New(&builder)}
+		e := logger.Entry{
+			Level:   logger.ErrorLevel,
+			Message: entry.Message,
+			Fields: []logger.Field{
+				logger.String("k1", "v1"),
+				logger.Group("http"),
+				logger.Int("status", 200),
+				logger.Group("db"),
+				logger.String("k2", "v2"),
+			},
+			Error: errors.New("err"),
+		}
+		// when
+		adapter.Log(ctx, e)
+		// then
+		var out map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(builder.String()), &out))
+		assert.Equal(t, "v1", out["k1"])
+		assert.Equal(t, "err", out["error"])
+		expectedGroup := map[string]interface{}{
+			"status": 200.0,
+			"db": map[string]interface{}{
+				"k2": "v2",
+			},
+		}
+		assert.Equal(t, expectedGroup, out["http"])
+	})
+
+	t.Run("should log joined errors with type and causes with their types", func(t *testing.T) {
+		var builder strings.Builder
+		adapter := zerologadapter.Adapter{Logger: zerolog.New(&builder)}
+		// when
+		adapter.Log(ctx, logger.Entry{
+			Level:   logger.ErrorLevel,
+			Message: entry.Message,
+			Error:   errors.Join(errors.New("a"), errors.New("b")),
+		})
+		// then
+		var out map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(builder.String()), &out))
+		assert.Equal(t, "a\nb", out["error"])
+		assert.Equal(t, "*errors.joinError", out["errorType"])
+		assert.Equal(t, []interface{}{"a", "b"}, out["errorCauses"])
+		assert.Equal(t, []interface{}{"*errors.errorString", "*errors.errorString"}, out["errorCauseTypes"])
+	})
+
+	t.Run("should log entry name", func(t *testing.T) {
+		var builder strings.Builder
+		adapter := zerologadapter.Adapter{Logger: zerolog.New(&builder)}
+		// when
+		adapter.Log(ctx, logger.Entry{
+			Level:   logger.InfoLevel,
+			Message: entry.Message,
+			Name:    "payments.db",
+		})
+		// then
+		var out map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(builder.String()), &out))
+		assert.Equal(t, "payments.db", out[logger.NameKey])
+	})
+
+	t.Run("should log entry stack trace", func(t *testing.T) {
+		var builder strings.Builder
+		adapter := zerologadapter.Adapter{Logger: zerolog.New(&builder)}
+		var pcs [2]uintptr
+		runtime.Callers(1, pcs[:])
+		stack := logger.Stack(pcs[:])
+		e := entry
+		e.Stack = stack
+		// when
+		adapter.Log(ctx, e)
+		// then
+		msg := unmarshalZerologMessage(t, builder.String())
+		assert.Equal(t, stack.String(), msg.Stack)
+	})
+
+	t.Run("should log entry time when Timestamp is enabled", func(t *testing.T) {
+		var builder strings.Builder
+		adapter := zerologadapter.Adapter{Logger: zerolog.New(&builder), Timestamp: true}
+		e := entry
+		e.Time = time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+		// when
+		adapter.Log(ctx, e)
+		// then
+		msg := unmarshalZerologMessage(t, builder.String())
+		assert.True(t, e.Time.Equal(msg.Time), "expected %s, got %s", e.Time, msg.Time)
+	})
+
+	t.Run("should log current time when Timestamp is enabled and entry time is zero", func(t *testing.T) {
+		var builder strings.Builder
+		adapter := zerologadapter.Adapter{Logger: zerolog.New(&builder), Timestamp: true}
+		// when
+		adapter.Log(ctx, entry)
+		// then
+		msg := unmarshalZerologMessage(t, builder.String())
+		assert.False(t, msg.Time.IsZero())
+	})
+
+	t.Run("should not log time when Timestamp is disabled", func(t *testing.T) {
+		var builder strings.Builder
+		adapter := zerologadapter.Adapter{Logger: zerolog.New(&builder)}
+		e := entry
+		e.Time = time.Now()
+		// when
+		adapter.Log(ctx, e)
+		// then
+		msg := unmarshalZerologMessage(t, builder.String())
+		assert.True(t, msg.Time.IsZero())
+	})
+
 	adaptertest.Run(t, adaptertest.Subject{
 		NewAdapter: func(writer io.Writer) logger.Adapter {
 			return zerologadapter.Adapter{Logger: zerolog.New(writer)}
@@ -41,6 +157,18 @@ func TestAdapter_Log(t *testing.T) {
 	})
 }
 
+func TestAdapter_Enabled(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should use zerolog logger level", func(t *testing.T) {
+		adapter := zerologadapter.Adapter{Logger: zerolog.New(io.Discard).Level(zerolog.WarnLevel)}
+		assert.False(t, adapter.Enabled(ctx, logger.DebugLevel))
+		assert.False(t, adapter.Enabled(ctx, logger.InfoLevel))
+		assert.True(t, adapter.Enabled(ctx, logger.WarnLevel))
+		assert.True(t, adapter.Enabled(ctx, logger.ErrorLevel))
+	})
+}
+
 var levelsMapping = map[string]logger.Level{
 	"debug": logger.DebugLevel,
 	"info":  logger.InfoLevel,
@@ -51,10 +179,7 @@ var levelsMapping = map[string]logger.Level{
 func unmarshalMessage(t *testing.T, line string) adaptertest.Message {
 	t.Helper()
 
-	out := zerologMessage{}
-	bytes := []byte(line)
-	err := json.Unmarshal(bytes, &out)
-	require.NoError(t, err)
+	out := unmarshalZerologMessage(t, line)
 
 	return adaptertest.Message{
 		Level:          levelsMapping[out.Level],
@@ -64,15 +189,30 @@ func unmarshalMessage(t *testing.T, line string) adaptertest.Message {
 		IntField:       out.IntField,
 		Int64Field:     out.Int64Field,
 		Float32Field:   out.Float32Field,
+		Uint64Field:    out.Uint64Field,
 		Float64Field:   out.Float64Field,
+		BoolField:      out.BoolField,
 		TimeField:      out.TimeField,
 		InterfaceField: out.InterfaceField,
 	}
 }
 
+func unmarshalZerologMessage(t *testing.T, line string) zerologMessage {
+	t.Helper()
+
+	out := zerologMessage{}
+	bytes := []byte(line)
+	err := json.Unmarshal(bytes, &out)
+	require.NoError(t, err)
+
+	return out
+}
+
 type zerologMessage struct {
 	Level   string
 	Message string
+	Time    time.Time
+	Stack   string
 
 	// fields
 	Error          string
@@ -80,7 +220,9 @@ type zerologMessage struct {
 	IntField       int
 	Int64Field     int64
 	Float32Field   float32
+	Uint64Field    uint64
 	Float64Field   float64
+	BoolField      bool
 	TimeField      time.Time
 	InterfaceField adaptertest.InterfaceField
 }
@@ -1,6 +1,6 @@
 module github.com/elgopher/yala
 
-go 1.19
+go 1.21
 
 require (
 	github.com/golang/glog v1.2.4
@@ -3,7 +3,6 @@ package main
 import (
 	"context"
 	"os"
-	"runtime"
 
 	"github.com/elgopher/yala/adapter/zerologadapter"
 	"github.com/elgopher/yala/logger"
@@ -42,12 +41,21 @@ type ReportCallerAdapter struct {
 func (a ReportCallerAdapter) Log(ctx context.Context, entry logger.Entry) {
 	entry.SkippedCallerFrames++ // each middleware adapter must additionally skip one frame (at least)
 
-	if _, file, line, ok := runtime.Caller(entry.SkippedCallerFrames); ok {
-		entry = entry.WithFields(logger.Fields{
-			"file": file,
-			"line": line,
-		})
+	// caller is resolved from program counter captured by the logger, so it does not matter how many middlewares
+	// were executed before
+	if frame, ok := entry.Caller(); ok {
+		entry = entry.WithKeyValues("file", frame.File, "line", frame.Line)
 	}
 
 	a.NextAdapter.Log(ctx, entry)
 }
+
+// ReportsCaller returns true, so the logger captures the program counter used by entry.Caller.
+func (a ReportCallerAdapter) ReportsCaller() bool {
+	return true
+}
+
+// Enabled passes the check to the next adapter, so caller information is not even computed for disabled levels.
+func (a ReportCallerAdapter) Enabled(ctx context.Context, level logger.Level) bool {
+	return logger.Enabled(ctx, a.NextAdapter, level)
+}
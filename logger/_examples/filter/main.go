@@ -43,3 +43,8 @@ func (a FilterOutMessages) Log(ctx context.Context, entry logger.Entry) {
 	entry.SkippedCallerFrames++ // each middleware adapter must additionally skip one frame
 	a.NextAdapter.Log(ctx, entry)
 }
+
+// Enabled passes the level check to the next adapter (see logger.LevelEnabler).
+func (a FilterOutMessages) Enabled(ctx context.Context, level logger.Level) bool {
+	return logger.Enabled(ctx, a.NextAdapter, level)
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/elgopher/yala/adapter/console"
+	"github.com/elgopher/yala/logger"
+)
+
+// Global loggers of imaginary libraries. Usually each library registers its logger in init function
+// using logger.Register(&log), which uses the package path of the library as a name.
+var (
+	paymentsLog logger.Global
+	ordersLog   logger.Global
+)
+
+func init() {
+	logger.RegisterAs("example.com/payments", &paymentsLog)
+	logger.RegisterAs("example.com/orders", &ordersLog)
+}
+
+// This example shows how to set adapter for all registered global loggers at once.
+func main() {
+	fmt.Println("Registered loggers:", logger.Registered())
+
+	logger.SetAdapterForAll(console.StdoutAdapter())
+
+	ctx := context.Background()
+	paymentsLog.Info(ctx, "logged by payments library")
+	ordersLog.Info(ctx, "logged by orders library")
+
+	// adapter can be set for loggers matching the pattern too:
+	logger.SetAdapterFor("example.com/payments/...", console.StderrAdapter())
+	paymentsLog.Info(ctx, "logged to stderr")
+}
@@ -34,12 +34,11 @@ func (r RenameFieldsAdapter) Log(ctx context.Context, entry logger.Entry) {
 	fields := make([]logger.Field, len(entry.Fields)) // Create a new slice in order to be concurrency-safe
 
 	for i, field := range entry.Fields {
-		key := field.Key
-		if key == r.From {
-			key = r.To
+		if field.Key == r.From {
+			field.Key = r.To // copy the whole field, because value of typed field is not stored in field.Value
 		}
 
-		fields[i] = logger.Field{Key: key, Value: field.Value}
+		fields[i] = field
 	}
 
 	entry.Fields = fields
@@ -47,3 +46,8 @@ func (r RenameFieldsAdapter) Log(ctx context.Context, entry logger.Entry) {
 
 	r.NextAdapter.Log(ctx, entry)
 }
+
+// Enabled implements logger.LevelEnabler. Renaming fields does not change the level, so the check is passed further.
+func (r RenameFieldsAdapter) Enabled(ctx context.Context, level logger.Level) bool {
+	return logger.Enabled(ctx, r.NextAdapter, level)
+}
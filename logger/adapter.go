@@ -5,7 +5,11 @@ package logger
 
 import (
 	"context"
+	"fmt"
+	"runtime"
 	"strconv"
+	"strings"
+	"time"
 )
 
 // Adapter is an interface to be implemented by logger adapters.
@@ -13,10 +17,96 @@ type Adapter interface {
 	Log(context.Context, Entry)
 }
 
+// LevelEnabler is an optional interface which can be implemented by logger.Adapter. It reports whether the entry
+// with given level will be logged. Thanks to that Logger and Global do not have to create entries which would be
+// discarded anyway.
+//
+// Middleware (decorator) adapters should implement this interface too, passing the check to the next adapter using
+// Enabled function.
+type LevelEnabler interface {
+	Enabled(ctx context.Context, level Level) bool
+}
+
+// Enabled returns false if adapter implements LevelEnabler and reports that level is disabled. Otherwise, it returns
+// true.
+func Enabled(ctx context.Context, adapter Adapter, level Level) bool {
+	if enabler, ok := adapter.(LevelEnabler); ok {
+		return enabler.Enabled(ctx, level)
+	}
+
+	return true
+}
+
+// Syncer is an optional interface which can be implemented by logger.Adapter buffering entries. Sync writes all
+// buffered entries, for example before application exits.
+//
+// Middleware (decorator) adapters should implement this interface too, passing the call to the next adapter using
+// Sync function.
+type Syncer interface {
+	Sync() error
+}
+
+// Sync executes Sync method if adapter implements Syncer. Otherwise, it returns nil.
+func Sync(adapter Adapter) error {
+	if syncer, ok := adapter.(Syncer); ok {
+		return syncer.Sync()
+	}
+
+	return nil
+}
+
+// Closer is an optional interface which can be implemented by logger.Adapter. Close writes all buffered entries and
+// releases resources, such as background goroutines. Adapter should not be used after Close.
+//
+// Middleware (decorator) adapters should implement this interface too, passing the call to the next adapter using
+// Close function.
+type Closer interface {
+	Close() error
+}
+
+// Close executes Close method if adapter implements Closer. Otherwise, it returns nil.
+func Close(adapter Adapter) error {
+	if closer, ok := adapter.(Closer); ok {
+		return closer.Close()
+	}
+
+	return nil
+}
+
+// CallerReporter is an optional interface which can be implemented by logger.Adapter using Entry.PC (for example
+// with Entry.Caller). Capturing PC is relatively expensive, therefore Logger and Global capture it only when
+// the adapter implements this interface and ReportsCaller returns true. Otherwise, only SkippedCallerFrames is set.
+//
+// Middleware (decorator) adapters should implement this interface too, passing the check to the next adapter using
+// ReportsCaller function.
+type CallerReporter interface {
+	ReportsCaller() bool
+}
+
+// ReportsCaller returns true if adapter implements CallerReporter and reports that it uses Entry.PC. Otherwise, it
+// returns false.
+func ReportsCaller(adapter Adapter) bool {
+	if reporter, ok := adapter.(CallerReporter); ok {
+		return reporter.ReportsCaller()
+	}
+
+	return false
+}
+
 // Entry is a logging entry created by logger and passed to adapter.
 type Entry struct {
 	Level   Level
 	Message string
+	// Name is a dot-separated name of the logger which created the entry, for example "payments.db.pool". It can be
+	// used to identify the library which logged the entry. Adapters should log it using logger name provided by
+	// the logging library, or as a field with NameKey. Name is empty by default. See Logger.Named.
+	Name string
+	// Time is the time when the Logger or Global method was called. Adapters should use it instead of the current time,
+	// because the entry might be passed to the adapter with a delay (for example by a buffering middleware).
+	//
+	// Time can be zero, when the entry was not created by the logger. In such case the adapter should use the current
+	// time.
+	Time time.Time
 
 	// Fields contains all accumulated fields, in the order they were appended.
 	//
@@ -26,11 +116,35 @@ type Entry struct {
 	// To update fields you can rewrite entire slice.
 	//
 	// Fields can be nil.
+	//
+	// Fields created with Group (KindGroup) open a group. All subsequent fields are nested in this group.
 	Fields []Field
 
 	Error error // Error can be nil
 	// SkippedCallerFrames can be used by logger.Adapter to extract caller information (file and line number)
 	SkippedCallerFrames int
+	// PC is a program counter of the function which logged the entry. It is captured by the logger, so adapters
+	// can get caller information using Caller method, no matter how many middlewares were executed before.
+	//
+	// PC is captured only for adapters implementing CallerReporter. PC can be zero, when the entry was not created
+	// by the logger.
+	PC uintptr
+	// Stack is a stack trace captured when the entry was logged. Stack is nil by default. It can be captured using
+	// middleware, such as stacktrace.Adapter.
+	Stack Stack
+}
+
+// Caller returns information about the function which logged the entry, such as file, line number and function name.
+// The frame is resolved from PC on each call. It returns false when PC is zero.
+func (e Entry) Caller() (runtime.Frame, bool) {
+	if e.PC == 0 {
+		return runtime.Frame{}, false
+	}
+
+	frames := runtime.CallersFrames([]uintptr{e.PC})
+	frame, _ := frames.Next()
+
+	return frame, frame.PC != 0
 }
 
 // With creates a new entry with additional field.
@@ -44,6 +158,23 @@ func (e Entry) With(field Field) Entry {
 	return e
 }
 
+// withFieldsPrepended creates a new entry with contextFields and errorFields inserted before entry fields. Thanks to
+// that, these fields are never nested in groups.
+func (e Entry) withFieldsPrepended(contextFields, errorFields []Field) Entry {
+	prependedLen := len(contextFields) + len(errorFields)
+	if prependedLen == 0 {
+		return e
+	}
+
+	fields := make([]Field, prependedLen+len(e.Fields))
+	copy(fields, contextFields)
+	copy(fields[len(contextFields):], errorFields)
+	copy(fields[prependedLen:], e.Fields)
+	e.Fields = fields
+
+	return e
+}
+
 type Fields map[string]interface{}
 
 // WithFields creates a new entry with additional fields. Fields will be appended, not replaced.
@@ -58,13 +189,84 @@ func (e Entry) WithFields(fields Fields) Entry {
 	e.Fields = slice
 
 	for k, v := range fields {
-		e.Fields[fieldsLength] = Field{k, v}
+		e.Fields[fieldsLength] = Field{Key: k, Value: v}
 		fieldsLength++
 	}
 
 	return e
 }
 
+// BadKey is a key of the field created by Entry.WithKeyValues, when the key is not a string or the value is missing.
+const BadKey = "!BADKEY"
+
+// NameKey is a key of the field which should be used by adapters to log Entry.Name, when the logging library does
+// not support logger names.
+const NameKey = "logger"
+
+// WithName returns a new entry with name appended to Entry.Name, separated with a dot. Empty name is ignored.
+func (e Entry) WithName(name string) Entry {
+	switch {
+	case name == "":
+	case e.Name == "":
+		e.Name = name
+	default:
+		e.Name += "." + name
+	}
+
+	return e
+}
+
+// WithKeyValues creates a new entry with additional fields created from alternating keys and values, for example:
+//
+//	entry.WithKeyValues("key1", value1, "key2", value2)
+//
+// Fields are appended in the given order. Argument of type Field is appended as is, without consuming the next argument.
+// When the key is not a string, or the value for the last key is missing, the field with BadKey is appended instead.
+func (e Entry) WithKeyValues(keyValues ...interface{}) Entry {
+	if len(keyValues) == 0 {
+		return e
+	}
+
+	fields := make([]Field, len(e.Fields), len(e.Fields)+countKeyValueFields(keyValues))
+	copy(fields, e.Fields)
+
+	for len(keyValues) > 0 {
+		var field Field
+		field, keyValues = nextKeyValueField(keyValues)
+		fields = append(fields, field)
+	}
+
+	e.Fields = fields
+
+	return e
+}
+
+func countKeyValueFields(keyValues []interface{}) int {
+	count := 0
+
+	for len(keyValues) > 0 {
+		_, keyValues = nextKeyValueField(keyValues)
+		count++
+	}
+
+	return count
+}
+
+func nextKeyValueField(keyValues []interface{}) (Field, []interface{}) {
+	switch key := keyValues[0].(type) {
+	case Field:
+		return key, keyValues[1:]
+	case string:
+		if len(keyValues) == 1 {
+			return Field{Key: BadKey, Value: key}, nil
+		}
+
+		return Field{Key: key, Value: keyValues[1]}, keyValues[2:]
+	default:
+		return Field{Key: BadKey, Value: key}, keyValues[1:]
+	}
+}
+
 // Level is a severity level of message. Use Level.MoreSevereThan to compare two levels.
 type Level int8
 
@@ -101,8 +303,52 @@ func (l Level) MoreSevereThan(other Level) bool {
 	return l > other
 }
 
-// Field contains key-value pair.
-type Field struct {
-	Key   string
-	Value interface{}
+// ParseLevel converts a string to Level. It accepts strings returned by Level.String (case-insensitive), "WARNING"
+// and numbers of custom levels, for example "2".
+func ParseLevel(s string) (Level, error) {
+	switch strings.ToUpper(s) {
+	case "DEBUG":
+		return DebugLevel, nil
+	case "INFO":
+		return InfoLevel, nil
+	case "WARN", "WARNING":
+		return WarnLevel, nil
+	case "ERROR":
+		return ErrorLevel, nil
+	}
+
+	level, err := strconv.ParseInt(s, 10, 8)
+	if err != nil {
+		return 0, fmt.Errorf("invalid level %q", s)
+	}
+
+	return Level(level), nil
+}
+
+// MarshalText converts the Level to text using Level.String. It implements encoding.TextMarshaler, therefore Level is
+// also marshalled to JSON string.
+func (l Level) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+// UnmarshalText converts text to Level using ParseLevel. It implements encoding.TextUnmarshaler, therefore Level is
+// also unmarshalled from JSON string.
+func (l *Level) UnmarshalText(text []byte) error {
+	level, err := ParseLevel(string(text))
+	if err != nil {
+		return err
+	}
+
+	*l = level
+
+	return nil
+}
+
+// Set sets the level parsed using ParseLevel. Together with Level.String it implements flag.Value, so the level can
+// be set using command-line flag:
+//
+//	level := logger.InfoLevel
+//	flag.Var(&level, "log-level", "minimum level of logged messages")
+func (l *Level) Set(s string) error {
+	return l.UnmarshalText([]byte(s))
 }
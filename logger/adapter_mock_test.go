@@ -6,6 +6,7 @@ package logger_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/elgopher/yala/logger"
 	"github.com/stretchr/testify/assert"
@@ -13,11 +14,17 @@ import (
 )
 
 type adapterMock struct {
-	entries []logger.Entry
+	entries  []logger.Entry
+	contexts []context.Context
 }
 
-func (a *adapterMock) Log(_ context.Context, entry logger.Entry) {
+func (a *adapterMock) Log(ctx context.Context, entry logger.Entry) {
 	a.entries = append(a.entries, entry)
+	a.contexts = append(a.contexts, ctx)
+}
+
+func (a *adapterMock) ReportsCaller() bool {
+	return true
 }
 
 func (a *adapterMock) HasExactlyOneEntry(t *testing.T, expected logger.Entry) {
@@ -25,6 +32,16 @@ func (a *adapterMock) HasExactlyOneEntry(t *testing.T, expected logger.Entry) {
 
 	require.Len(t, a.entries, 1)
 	actual := a.entries[0]
+	if expected.Time.IsZero() {
+		assert.False(t, actual.Time.IsZero(), "entry has zero time")
+		actual.Time = time.Time{} // exact time is checked only when expected
+	}
+
+	if expected.PC == 0 {
+		assert.NotZero(t, actual.PC, "entry has zero PC")
+		actual.PC = 0 // exact PC is checked only when expected
+	}
+
 	assert.Equal(t, expected, actual)
 }
 
@@ -51,3 +68,31 @@ func (a *adapterMock) HasExactlyOneEntryWithSkippedCallerFrames(t *testing.T, ex
 	actual := a.entries[0].SkippedCallerFrames
 	assert.Equal(t, expected, actual)
 }
+
+type levelEnablerAdapterMock struct {
+	adapterMock
+	minLevel logger.Level
+}
+
+func (a *levelEnablerAdapterMock) Enabled(_ context.Context, level logger.Level) bool {
+	return !a.minLevel.MoreSevereThan(level)
+}
+
+type syncCloserAdapterMock struct {
+	adapterMock
+	err         error
+	syncCalled  int
+	closeCalled int
+}
+
+func (a *syncCloserAdapterMock) Sync() error {
+	a.syncCalled++
+
+	return a.err
+}
+
+func (a *syncCloserAdapterMock) Close() error {
+	a.closeCalled++
+
+	return a.err
+}
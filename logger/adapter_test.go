@@ -1,6 +1,10 @@
 package logger_test
 
 import (
+	"encoding/json"
+	"flag"
+	"runtime"
+	"strings"
 	"testing"
 
 	"github.com/elgopher/yala/logger"
@@ -119,6 +123,138 @@ func TestEntry_WithFields(t *testing.T) {
 	})
 }
 
+func TestEntry_WithKeyValues(t *testing.T) {
+	t.Run("should copy entry when key values are empty", func(t *testing.T) {
+		entry := logger.Entry{}
+		newEntry := entry.WithKeyValues()
+		assert.Equal(t, entry, newEntry)
+	})
+
+	t.Run("should create new entry with fields in given order", func(t *testing.T) {
+		tests := map[string]struct {
+			keyValues      []interface{}
+			expectedFields []logger.Field
+		}{
+			"one key value": {
+				keyValues:      []interface{}{"k", "v"},
+				expectedFields: []logger.Field{{Key: "k", Value: "v"}},
+			},
+			"many key values": {
+				keyValues: []interface{}{"k3", 3, "k1", 1, "k2", 2},
+				expectedFields: []logger.Field{
+					{Key: "k3", Value: 3},
+					{Key: "k1", Value: 1},
+					{Key: "k2", Value: 2},
+				},
+			},
+			"missing value": {
+				keyValues: []interface{}{"k1", "v1", "k2"},
+				expectedFields: []logger.Field{
+					{Key: "k1", Value: "v1"},
+					{Key: logger.BadKey, Value: "k2"},
+				},
+			},
+			"non-string key": {
+				keyValues: []interface{}{1, "k", "v"},
+				expectedFields: []logger.Field{
+					{Key: logger.BadKey, Value: 1},
+					{Key: "k", Value: "v"},
+				},
+			},
+			"nil key": {
+				keyValues:      []interface{}{nil},
+				expectedFields: []logger.Field{{Key: logger.BadKey, Value: nil}},
+			},
+			"field": {
+				keyValues: []interface{}{logger.Field{Key: "k1", Value: "v1"}, "k2", "v2"},
+				expectedFields: []logger.Field{
+					{Key: "k1", Value: "v1"},
+					{Key: "k2", Value: "v2"},
+				},
+			},
+		}
+
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				entry := logger.Entry{}
+				// when
+				newEntry := entry.WithKeyValues(test.keyValues...)
+				// then
+				assert.Equal(t, test.expectedFields, newEntry.Fields)
+				// and leave original entry unchanged
+				assert.Empty(t, entry.Fields)
+			})
+		}
+	})
+
+	t.Run("should append fields to existing fields", func(t *testing.T) {
+		existingField := logger.Field{Key: "k1", Value: "v1"}
+		entry := logger.Entry{
+			Fields: make([]logger.Field, 1, 2), // spare capacity must not be used
+		}
+		entry.Fields[0] = existingField
+		// when
+		newEntry := entry.WithKeyValues("k2", "v2")
+		_ = entry.WithKeyValues("k3", "v3")
+		// then
+		assert.Equal(t,
+			[]logger.Field{
+				existingField,
+				{Key: "k2", Value: "v2"},
+			},
+			newEntry.Fields,
+		)
+	})
+}
+
+func TestEntry_WithName(t *testing.T) {
+	tests := map[string]struct {
+		entry        logger.Entry
+		name         string
+		expectedName string
+	}{
+		"first name": {
+			name:         "a",
+			expectedName: "a",
+		},
+		"second name": {
+			entry:        logger.Entry{Name: "a"},
+			name:         "b",
+			expectedName: "a.b",
+		},
+		"empty name": {
+			entry:        logger.Entry{Name: "a"},
+			name:         "",
+			expectedName: "a",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expectedName, test.entry.WithName(test.name).Name)
+		})
+	}
+}
+
+func TestEntry_Caller(t *testing.T) {
+	t.Run("should return false when PC is zero", func(t *testing.T) {
+		_, ok := logger.Entry{}.Caller()
+		assert.False(t, ok)
+	})
+
+	t.Run("should return frame for PC", func(t *testing.T) {
+		pc, file, line, _ := runtime.Caller(0)
+		entry := logger.Entry{PC: pc}
+		// when
+		frame, ok := entry.Caller()
+		// then
+		require.True(t, ok)
+		assert.Equal(t, file, frame.File)
+		assert.Equal(t, line, frame.Line)
+		assert.True(t, strings.HasPrefix(frame.Function, "github.com/elgopher/yala/logger_test.TestEntry_Caller."))
+	})
+}
+
 func TestLevel_MoreSevereThan(t *testing.T) {
 	t.Run("should return true", func(t *testing.T) {
 		assert.True(t, logger.InfoLevel.MoreSevereThan(logger.DebugLevel))
@@ -157,3 +293,72 @@ func TestLevel_String(t *testing.T) {
 		assert.Equal(t, "10", logger.Level(10).String())
 	})
 }
+
+func TestParseLevel(t *testing.T) {
+	t.Run("should parse level", func(t *testing.T) {
+		tests := map[string]logger.Level{
+			"DEBUG":   logger.DebugLevel,
+			"debug":   logger.DebugLevel,
+			"INFO":    logger.InfoLevel,
+			"Info":    logger.InfoLevel,
+			"WARN":    logger.WarnLevel,
+			"warning": logger.WarnLevel,
+			"ERROR":   logger.ErrorLevel,
+			"10":      logger.Level(10),
+			"-2":      logger.Level(-2),
+		}
+
+		for s, expectedLevel := range tests {
+			t.Run(s, func(t *testing.T) {
+				level, err := logger.ParseLevel(s)
+				require.NoError(t, err)
+				assert.Equal(t, expectedLevel, level)
+			})
+		}
+	})
+
+	t.Run("should return error for invalid level", func(t *testing.T) {
+		for _, s := range []string{"", "invalid", "1000"} {
+			t.Run(s, func(t *testing.T) {
+				_, err := logger.ParseLevel(s)
+				assert.Error(t, err)
+			})
+		}
+	})
+}
+
+func TestLevel_MarshalJSON(t *testing.T) {
+	t.Run("should marshal level to JSON string", func(t *testing.T) {
+		bytes, err := json.Marshal(map[string]logger.Level{"level": logger.WarnLevel})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"level":"WARN"}`, string(bytes))
+	})
+
+	t.Run("should unmarshal level from JSON string", func(t *testing.T) {
+		var out struct {
+			Level logger.Level
+		}
+		err := json.Unmarshal([]byte(`{"Level":"error"}`), &out)
+		require.NoError(t, err)
+		assert.Equal(t, logger.ErrorLevel, out.Level)
+	})
+
+	t.Run("should return error when unmarshalling invalid level", func(t *testing.T) {
+		var level logger.Level
+		err := json.Unmarshal([]byte(`"invalid"`), &level)
+		assert.Error(t, err)
+	})
+}
+
+func TestLevel_Set(t *testing.T) {
+	t.Run("should set level using command-line flag", func(t *testing.T) {
+		level := logger.InfoLevel
+		flags := flag.NewFlagSet("test", flag.ContinueOnError)
+		flags.Var(&level, "log-level", "")
+		// when
+		err := flags.Parse([]string{"-log-level=debug"})
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, logger.DebugLevel, level)
+	})
+}
@@ -0,0 +1,37 @@
+// (c) 2022 Jacek Olszak
+// This code is licensed under MIT license (see LICENSE for details)
+
+package logger
+
+import "context"
+
+type contextFieldsKey struct{}
+
+// ContextWithFields returns a copy of ctx with additional request-scoped fields. Fields are appended to fields
+// already stored in ctx. Logger and Global add these fields to each entry logged with returned context.
+func ContextWithFields(ctx context.Context, fields ...Field) context.Context {
+	if len(fields) == 0 {
+		return ctx
+	}
+
+	existingFields := FieldsFromContext(ctx)
+
+	newFields := make([]Field, len(existingFields), len(existingFields)+len(fields))
+	copy(newFields, existingFields)
+	newFields = append(newFields, fields...)
+
+	return context.WithValue(ctx, contextFieldsKey{}, newFields)
+}
+
+// FieldsFromContext returns fields stored in ctx using ContextWithFields. It returns nil if there are no fields.
+//
+// Please do not modify the returned slice, because it is shared by all entries logged with ctx.
+func FieldsFromContext(ctx context.Context) []Field {
+	if ctx == nil {
+		return nil
+	}
+
+	fields, _ := ctx.Value(contextFieldsKey{}).([]Field)
+
+	return fields
+}
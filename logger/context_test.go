@@ -0,0 +1,124 @@
+// (c) 2022 Jacek Olszak
+// This code is licensed under MIT license (see LICENSE for details)
+
+package logger_test
+
+import (
+	"testing"
+
+	"github.com/elgopher/yala/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContextWithFields(t *testing.T) {
+	field1 := logger.String("k1", "v1")
+	field2 := logger.String("k2", "v2")
+
+	t.Run("should return nil when there are no fields in context", func(t *testing.T) {
+		assert.Nil(t, logger.FieldsFromContext(ctx))
+	})
+
+	t.Run("should return nil for nil context", func(t *testing.T) {
+		assert.Nil(t, logger.FieldsFromContext(nil)) //nolint:staticcheck // nil context is tested on purpose
+	})
+
+	t.Run("should return the same context when no fields are given", func(t *testing.T) {
+		assert.Equal(t, ctx, logger.ContextWithFields(ctx))
+	})
+
+	t.Run("should store fields in context", func(t *testing.T) {
+		// when
+		newCtx := logger.ContextWithFields(ctx, field1, field2)
+		// then
+		assert.Equal(t, []logger.Field{field1, field2}, logger.FieldsFromContext(newCtx))
+	})
+
+	t.Run("should append fields to fields already stored in context", func(t *testing.T) {
+		parentCtx := logger.ContextWithFields(ctx, field1)
+		// when
+		newCtx := logger.ContextWithFields(parentCtx, field2)
+		// then
+		assert.Equal(t, []logger.Field{field1, field2}, logger.FieldsFromContext(newCtx))
+		assert.Equal(t, []logger.Field{field1}, logger.FieldsFromContext(parentCtx))
+	})
+
+	t.Run("should not modify fields of parent context", func(t *testing.T) {
+		parentCtx := logger.ContextWithFields(ctx, field1)
+		// when
+		ctx1 := logger.ContextWithFields(parentCtx, field2)
+		ctx2 := logger.ContextWithFields(parentCtx, field1)
+		// then
+		assert.Equal(t, []logger.Field{field1, field2}, logger.FieldsFromContext(ctx1))
+		assert.Equal(t, []logger.Field{field1, field1}, logger.FieldsFromContext(ctx2))
+	})
+}
+
+func TestLogWithContextFields(t *testing.T) {
+	contextField := logger.String("request_id", "1")
+
+	loggers := map[string]func(adapter logger.Adapter) anyLogger{
+		"normal": func(adapter logger.Adapter) anyLogger {
+			return logger.WithAdapter(adapter).With("k", "v")
+		},
+		"global": func(adapter logger.Adapter) anyLogger {
+			var global logger.Global
+			global.SetAdapter(adapter)
+
+			return global.With("k", "v")
+		},
+	}
+
+	for name, newLogger := range loggers {
+		t.Run(name, func(t *testing.T) {
+			t.Run("should prepend fields from context", func(t *testing.T) {
+				adapter := &adapterMock{}
+				log := newLogger(adapter)
+				ctxWithFields := logger.ContextWithFields(ctx, contextField)
+				// when
+				log.Info(ctxWithFields, message)
+				// then
+				adapter.HasExactlyOneEntryWithFields(t, []logger.Field{
+					contextField,
+					{Key: "k", Value: "v"},
+				})
+			})
+
+			t.Run("should not modify fields stored in context", func(t *testing.T) {
+				adapter := &adapterMock{}
+				log := newLogger(adapter)
+				ctxWithFields := logger.ContextWithFields(ctx, contextField)
+				// when
+				log.Info(ctxWithFields, message)
+				// then
+				require.Len(t, adapter.entries, 1)
+				assert.Equal(t, []logger.Field{contextField}, logger.FieldsFromContext(ctxWithFields))
+			})
+		})
+	}
+
+	t.Run("should not nest fields from context in group", func(t *testing.T) {
+		adapter := &adapterMock{}
+		log := logger.WithAdapter(adapter).WithGroup("g")
+		ctxWithFields := logger.ContextWithFields(ctx, contextField)
+		// when
+		log.InfoKV(ctxWithFields, message, "k", "v")
+		// then
+		adapter.HasExactlyOneEntryWithFields(t, []logger.Field{
+			contextField,
+			logger.Group("g"),
+			{Key: "k", Value: "v"},
+		})
+	})
+
+	t.Run("should pass context to adapter", func(t *testing.T) {
+		adapter := &adapterMock{}
+		log := logger.WithAdapter(adapter)
+		ctxWithFields := logger.ContextWithFields(ctx, contextField)
+		// when
+		log.Info(ctxWithFields, message)
+		// then
+		require.Len(t, adapter.contexts, 1)
+		assert.Equal(t, ctxWithFields, adapter.contexts[0])
+	})
+}
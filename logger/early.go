@@ -0,0 +1,206 @@
+// (c) 2022 Jacek Olszak
+// This code is licensed under MIT license (see LICENSE for details)
+
+package logger
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// EarlyEntries configures buffering of entries logged by Global loggers before SetAdapter was called. Such entries
+// are passed to the adapter when Global.SetAdapter is called for the first time, with their original times and callers.
+// See BufferEarlyEntries.
+type EarlyEntries struct {
+	// Size is the maximum number of entries buffered by each Global (including its child loggers). When the buffer is
+	// full, the oldest entry is dropped. Zero disables buffering.
+	Size int
+	// FallbackAfter is the time after which buffered entries are passed to the Fallback adapter, if SetAdapter was not
+	// called in the meantime. The time is measured from the first buffered entry. Zero means that entries are
+	// buffered until SetAdapter is called.
+	FallbackAfter time.Duration
+	// Fallback is an adapter used when SetAdapter was not called within FallbackAfter. By default, entries are
+	// printed to stderr.
+	Fallback Adapter
+}
+
+// Environment variables used to configure buffering of early entries, when BufferEarlyEntries was not called.
+const (
+	// EarlyEntriesEnv is the name of environment variable with EarlyEntries.Size, for example "100".
+	EarlyEntriesEnv = "YALA_EARLY_ENTRIES"
+	// EarlyEntriesFallbackAfterEnv is the name of environment variable with EarlyEntries.FallbackAfter, for example
+	// "10s".
+	EarlyEntriesFallbackAfterEnv = "YALA_EARLY_ENTRIES_FALLBACK_AFTER"
+)
+
+var earlyEntries struct {
+	sync.Mutex
+	config     EarlyEntries
+	configured bool
+}
+
+// BufferEarlyEntries enables (or disables, when config.Size is zero) buffering of entries logged by Global loggers
+// before SetAdapter was called. By default, such entries are discarded.
+//
+// It affects only Global loggers which did not log anything yet. Please note that init functions of imported packages
+// are executed before init functions of main package. Therefore, to buffer entries logged during initialization of
+// imported packages, please use EarlyEntriesEnv and EarlyEntriesFallbackAfterEnv environment variables instead.
+func BufferEarlyEntries(config EarlyEntries) {
+	earlyEntries.Lock()
+	defer earlyEntries.Unlock()
+
+	earlyEntries.config = config
+	earlyEntries.configured = true
+}
+
+func earlyEntriesConfig() EarlyEntries {
+	earlyEntries.Lock()
+	defer earlyEntries.Unlock()
+
+	if !earlyEntries.configured {
+		earlyEntries.config = earlyEntriesConfigFromEnv()
+		earlyEntries.configured = true
+	}
+
+	return earlyEntries.config
+}
+
+func earlyEntriesConfigFromEnv() EarlyEntries {
+	var config EarlyEntries
+
+	if size, err := strconv.Atoi(os.Getenv(EarlyEntriesEnv)); err == nil {
+		config.Size = size
+	}
+
+	if fallbackAfter, err := time.ParseDuration(os.Getenv(EarlyEntriesFallbackAfterEnv)); err == nil {
+		config.FallbackAfter = fallbackAfter
+	}
+
+	return config
+}
+
+// earlyEntriesBuffer is an initial adapter of Global, buffering entries until SetAdapter is called.
+type earlyEntriesBuffer struct {
+	mutex   sync.Mutex
+	config  EarlyEntries
+	entries []bufferedEntry
+	dropped int
+	timer   *time.Timer
+	next    Adapter // not nil when buffered entries were already passed to the next adapter
+}
+
+type bufferedEntry struct {
+	ctx   context.Context //nolint:containedctx
+	entry Entry
+}
+
+func newEarlyEntriesBuffer(config EarlyEntries) *earlyEntriesBuffer {
+	if config.Fallback == nil {
+		config.Fallback = stderrAdapter{}
+	}
+
+	return &earlyEntriesBuffer{config: config}
+}
+
+// Enabled returns true, because it is not known yet which levels will be enabled by the adapter.
+func (b *earlyEntriesBuffer) Enabled(ctx context.Context, level Level) bool {
+	b.mutex.Lock()
+	next := b.next
+	b.mutex.Unlock()
+
+	if next != nil {
+		return Enabled(ctx, next, level)
+	}
+
+	return true
+}
+
+// ReportsCaller returns true until entries are passed to the next adapter, because it is not known yet whether
+// the adapter uses Entry.PC.
+func (b *earlyEntriesBuffer) ReportsCaller() bool {
+	b.mutex.Lock()
+	next := b.next
+	b.mutex.Unlock()
+
+	return next == nil || ReportsCaller(next)
+}
+
+func (b *earlyEntriesBuffer) Log(ctx context.Context, entry Entry) {
+	b.mutex.Lock()
+
+	next := b.next
+	if next == nil {
+		b.append(ctx, entry)
+		b.mutex.Unlock()
+
+		return
+	}
+
+	b.mutex.Unlock()
+
+	entry.SkippedCallerFrames++
+	next.Log(ctx, entry)
+}
+
+func (b *earlyEntriesBuffer) append(ctx context.Context, entry Entry) {
+	if len(b.entries) == b.config.Size {
+		copy(b.entries, b.entries[1:])
+		b.entries = b.entries[:len(b.entries)-1]
+		b.dropped++
+	}
+
+	buffered := bufferedEntry{ctx: ctx, entry: entry}
+	if ctx != nil {
+		buffered.ctx = context.WithoutCancel(ctx)
+	}
+
+	b.entries = append(b.entries, buffered)
+
+	if b.timer == nil && b.config.FallbackAfter > 0 {
+		b.timer = time.AfterFunc(b.config.FallbackAfter, func() {
+			b.replay(b.config.Fallback)
+		})
+	}
+}
+
+// replay passes all buffered entries to the next adapter. Subsequent entries are passed directly to the next adapter.
+// Only the first call has effect.
+//
+// Buffered entries are passed from a different stack than they were logged. Therefore, their SkippedCallerFrames
+// are reset and the next adapter should use Entry.PC to report caller.
+func (b *earlyEntriesBuffer) replay(next Adapter) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.next != nil {
+		return
+	}
+
+	b.next = next
+
+	if b.timer != nil {
+		b.timer.Stop()
+	}
+
+	for _, buffered := range b.entries {
+		buffered.entry.SkippedCallerFrames = 0
+
+		if Enabled(buffered.ctx, next, buffered.entry.Level) {
+			next.Log(buffered.ctx, buffered.entry)
+		}
+	}
+
+	if b.dropped > 0 && Enabled(context.Background(), next, WarnLevel) {
+		next.Log(context.Background(), Entry{
+			Level:   WarnLevel,
+			Message: "Early log entries were dropped, because the buffer was full",
+			Time:    time.Now(),
+			Fields:  []Field{Int("dropped", b.dropped)},
+		})
+	}
+
+	b.entries = nil
+}
@@ -0,0 +1,217 @@
+// (c) 2022 Jacek Olszak
+// This code is licensed under MIT license (see LICENSE for details)
+
+package logger_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/elgopher/yala/adapter/logadapter"
+	"github.com/elgopher/yala/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBufferEarlyEntries(t *testing.T) {
+	bufferEarlyEntries := func(t *testing.T, config logger.EarlyEntries) {
+		t.Helper()
+		logger.BufferEarlyEntries(config)
+		t.Cleanup(func() {
+			logger.BufferEarlyEntries(logger.EarlyEntries{})
+		})
+	}
+
+	t.Run("should pass buffered entries to adapter", func(t *testing.T) {
+		bufferEarlyEntries(t, logger.EarlyEntries{Size: 10})
+
+		var global logger.Global
+		global.Debug(ctx, "debug")
+		global.Named("child").Info(ctx, "info")
+		adapter := &adapterMock{}
+		// when
+		global.SetAdapter(adapter)
+		// then
+		require.Len(t, adapter.entries, 2)
+		assert.Equal(t, "debug", adapter.entries[0].Message)
+		assert.Equal(t, "info", adapter.entries[1].Message)
+		assert.Equal(t, "child", adapter.entries[1].Name)
+	})
+
+	t.Run("should keep original time and caller", func(t *testing.T) {
+		bufferEarlyEntries(t, logger.EarlyEntries{Size: 10})
+
+		entryTime := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+		var global logger.Global
+		global.WithClock(func() time.Time { return entryTime }).Info(ctx, message)
+		adapter := &adapterMock{}
+		// when
+		global.SetAdapter(adapter)
+		// then
+		require.Len(t, adapter.entries, 1)
+		assert.Equal(t, entryTime, adapter.entries[0].Time)
+		caller, ok := adapter.entries[0].Caller()
+		require.True(t, ok)
+		assert.Contains(t, caller.Function, "TestBufferEarlyEntries")
+	})
+
+	t.Run("should print original caller using standard log", func(t *testing.T) {
+		bufferEarlyEntries(t, logger.EarlyEntries{Size: 10})
+
+		var global logger.Global
+		global.Info(ctx, message)
+		_, _, line, _ := runtime.Caller(0)
+		var builder strings.Builder
+		// when
+		global.SetAdapter(logadapter.Adapter(log.New(&builder, "", log.Lshortfile)))
+		// then
+		assert.Equal(t, fmt.Sprintf("early_test.go:%d: INFO message\n", line-1), builder.String())
+	})
+
+	t.Run("should not skip caller frames of SetAdapter", func(t *testing.T) {
+		bufferEarlyEntries(t, logger.EarlyEntries{Size: 10})
+
+		var global logger.Global
+		global.Info(ctx, message)
+		adapter := &adapterMock{}
+		// when
+		global.SetAdapter(adapter)
+		// then
+		adapter.HasExactlyOneEntryWithSkippedCallerFrames(t, 0)
+	})
+
+	t.Run("should pass buffered entry with nil context", func(t *testing.T) {
+		bufferEarlyEntries(t, logger.EarlyEntries{Size: 10})
+
+		var global logger.Global
+		global.Info(nil, message) //nolint:staticcheck // nil context is tested on purpose
+		adapter := &adapterMock{}
+		// when
+		global.SetAdapter(adapter)
+		// then
+		require.Len(t, adapter.contexts, 1)
+		assert.Nil(t, adapter.contexts[0])
+	})
+
+	t.Run("should not pass entries with levels disabled by adapter", func(t *testing.T) {
+		bufferEarlyEntries(t, logger.EarlyEntries{Size: 10})
+
+		var global logger.Global
+		global.Debug(ctx, "debug")
+		global.Warn(ctx, "warn")
+		adapter := &levelEnablerAdapterMock{minLevel: logger.InfoLevel}
+		// when
+		global.SetAdapter(adapter)
+		// then
+		require.Len(t, adapter.entries, 1)
+		assert.Equal(t, "warn", adapter.entries[0].Message)
+	})
+
+	t.Run("should drop the oldest entries when buffer is full", func(t *testing.T) {
+		bufferEarlyEntries(t, logger.EarlyEntries{Size: 2})
+
+		var global logger.Global
+		global.Info(ctx, "1")
+		global.Info(ctx, "2")
+		global.Info(ctx, "3")
+		adapter := &adapterMock{}
+		// when
+		global.SetAdapter(adapter)
+		// then
+		require.Len(t, adapter.entries, 3)
+		assert.Equal(t, "2", adapter.entries[0].Message)
+		assert.Equal(t, "3", adapter.entries[1].Message)
+		assert.Equal(t, logger.WarnLevel, adapter.entries[2].Level)
+		assert.Equal(t, []logger.Field{logger.Int("dropped", 1)}, adapter.entries[2].Fields)
+	})
+
+	t.Run("should pass entries only once", func(t *testing.T) {
+		bufferEarlyEntries(t, logger.EarlyEntries{Size: 10})
+
+		var global logger.Global
+		global.Info(ctx, message)
+		global.SetAdapter(&adapterMock{})
+		adapter := &adapterMock{}
+		// when
+		global.SetAdapter(adapter)
+		// then
+		assert.Empty(t, adapter.entries)
+	})
+
+	t.Run("should not buffer entries when buffering is disabled", func(t *testing.T) {
+		var global logger.Global
+		global.Info(ctx, message)
+		adapter := &adapterMock{}
+		// when
+		global.SetAdapter(adapter)
+		// then
+		assert.Empty(t, adapter.entries)
+	})
+
+	t.Run("should pass buffered entries to fallback adapter", func(t *testing.T) {
+		fallback := &recordingAdapter{}
+		bufferEarlyEntries(t, logger.EarlyEntries{
+			Size:          10,
+			FallbackAfter: time.Millisecond,
+			Fallback:      fallback,
+		})
+
+		var global logger.Global
+		// when
+		global.Info(ctx, "buffered")
+		// then
+		assert.Eventually(t, func() bool {
+			return len(fallback.Messages()) == 1
+		}, time.Second, time.Millisecond)
+		// and
+		global.Info(ctx, "not buffered")
+		assert.Equal(t, []string{"buffered", "not buffered"}, fallback.Messages())
+	})
+
+	t.Run("should pass entries to adapter set after fallback", func(t *testing.T) {
+		fallback := &recordingAdapter{}
+		bufferEarlyEntries(t, logger.EarlyEntries{
+			Size:          10,
+			FallbackAfter: time.Millisecond,
+			Fallback:      fallback,
+		})
+
+		var global logger.Global
+		global.Info(ctx, "buffered")
+		require.Eventually(t, func() bool {
+			return len(fallback.Messages()) == 1
+		}, time.Second, time.Millisecond)
+		adapter := &adapterMock{}
+		// when
+		global.SetAdapter(adapter)
+		global.Info(ctx, message)
+		// then
+		require.Len(t, adapter.entries, 1)
+		assert.Equal(t, message, adapter.entries[0].Message)
+	})
+}
+
+type recordingAdapter struct {
+	mutex    sync.Mutex
+	messages []string
+}
+
+func (r *recordingAdapter) Log(_ context.Context, entry logger.Entry) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.messages = append(r.messages, entry.Message)
+}
+
+func (r *recordingAdapter) Messages() []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return append([]string(nil), r.messages...)
+}
@@ -0,0 +1,111 @@
+// (c) 2022 Jacek Olszak
+// This code is licensed under MIT license (see LICENSE for details)
+
+package logger
+
+import (
+	"errors"
+	"fmt"
+)
+
+// WrapError returns an error wrapping err with additional fields. Logger and Global add these fields (in the same order)
+// to each entry logged with such error (or any error wrapping it), for example:
+//
+//	return logger.WrapError(err, logger.String("order_id", orderID))
+//
+// Returned error has the same message as err. It returns nil if err is nil.
+func WrapError(err error, fields ...Field) error {
+	if err == nil {
+		return nil
+	}
+
+	return &fieldsError{err: err, fields: fields}
+}
+
+type fieldsError struct {
+	err    error
+	fields []Field
+}
+
+func (e *fieldsError) Error() string {
+	return e.err.Error()
+}
+
+func (e *fieldsError) Unwrap() error {
+	return e.err
+}
+
+// Format formats the wrapped error, so verbs like %+v work the same as for the wrapped error.
+func (e *fieldsError) Format(state fmt.State, verb rune) {
+	_, _ = fmt.Fprintf(state, fmt.FormatString(state, verb), e.err)
+}
+
+// FieldsFromError returns fields attached to err, and to all errors in its tree, using WrapError. The tree is
+// traversed using errors.Unwrap (or Unwrap() []error method, implemented by errors.Join) in pre-order, so fields
+// of outer errors come first. It returns nil if there are no fields.
+func FieldsFromError(err error) []Field {
+	return appendFieldsFromError(nil, err)
+}
+
+func appendFieldsFromError(fields []Field, err error) []Field {
+	if err == nil {
+		return fields
+	}
+
+	if e, ok := err.(*fieldsError); ok { //nolint:errorlint // the tree is traversed manually
+		fields = append(fields, e.fields...)
+	}
+
+	if joinedErr, ok := err.(interface{ Unwrap() []error }); ok { //nolint:errorlint // the tree is traversed manually
+		for _, e := range joinedErr.Unwrap() {
+			fields = appendFieldsFromError(fields, e)
+		}
+
+		return fields
+	}
+
+	return appendFieldsFromError(fields, errors.Unwrap(err))
+}
+
+// ErrorType returns the name of the concrete type of the root cause of err, for example "*os.PathError". Errors
+// wrapping a single error (for example created with fmt.Errorf and %w verb, or with WrapError) are unwrapped until
+// the innermost error is found. Errors joining multiple errors have no single root cause, so the type of such error
+// is returned instead, for example "*errors.joinError". Adapters report types of joined errors separately, using
+// ErrorType for each of ErrorCauses.
+//
+// When err (or any error it wraps) implements ErrorType() string method, its result is returned instead. Errors
+// replacing other errors, for example to hide sensitive data, can use it to report the type of the original error.
+// It returns empty string if err is nil.
+func ErrorType(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	for {
+		if typedErr, ok := err.(interface{ ErrorType() string }); ok { //nolint:errorlint // the chain is traversed manually
+			return typedErr.ErrorType()
+		}
+
+		unwrapped := errors.Unwrap(err)
+		if unwrapped == nil {
+			return fmt.Sprintf("%T", err)
+		}
+
+		err = unwrapped
+	}
+}
+
+// ErrorCauses returns errors joined in err, for example using errors.Join or fmt.Errorf with multiple %w verbs.
+// Errors wrapping a single error are unwrapped until joined errors are found. It returns nil if err does not
+// join multiple errors.
+func ErrorCauses(err error) []error {
+	for err != nil {
+		if joinedErr, ok := err.(interface{ Unwrap() []error }); ok { //nolint:errorlint // the tree is traversed manually
+			return joinedErr.Unwrap()
+		}
+
+		err = errors.Unwrap(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,234 @@
+// (c) 2022 Jacek Olszak
+// This code is licensed under MIT license (see LICENSE for details)
+
+package logger_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/elgopher/yala/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrapError(t *testing.T) {
+	t.Run("should return nil for nil error", func(t *testing.T) {
+		assert.NoError(t, logger.WrapError(nil, logger.String("k", "v")))
+	})
+
+	t.Run("should wrap error", func(t *testing.T) {
+		// when
+		err := logger.WrapError(ErrSome, logger.String("k", "v"))
+		// then
+		assert.ErrorIs(t, err, ErrSome)
+		assert.Equal(t, ErrSome.Error(), err.Error())
+		assert.Equal(t, ErrSome, errors.Unwrap(err))
+	})
+
+	t.Run("should format the same way as wrapped error", func(t *testing.T) {
+		err := logger.WrapError(formattedError{}, logger.String("k", "v"))
+		assert.Equal(t, "formatted error +v", fmt.Sprintf("%+v", err))
+		assert.Equal(t, "formatted error s", fmt.Sprintf("%s", err))
+	})
+}
+
+func TestFieldsFromError(t *testing.T) {
+	t.Run("should return nil for nil error", func(t *testing.T) {
+		assert.Nil(t, logger.FieldsFromError(nil))
+	})
+
+	t.Run("should return nil for error without fields", func(t *testing.T) {
+		assert.Nil(t, logger.FieldsFromError(fmt.Errorf("wrapped: %w", ErrSome)))
+	})
+
+	t.Run("should return fields", func(t *testing.T) {
+		tests := map[string]struct {
+			err            error
+			expectedFields []logger.Field
+		}{
+			"wrapped error": {
+				err:            logger.WrapError(ErrSome, logger.String("k", "v")),
+				expectedFields: []logger.Field{logger.String("k", "v")},
+			},
+			"multiple fields in order": {
+				err:            logger.WrapError(ErrSome, logger.String("k3", "v"), logger.Int("k1", 1), logger.Bool("k2", true)),
+				expectedFields: []logger.Field{logger.String("k3", "v"), logger.Int("k1", 1), logger.Bool("k2", true)},
+			},
+			"error wrapped using fmt.Errorf": {
+				err:            fmt.Errorf("wrapped: %w", logger.WrapError(ErrSome, logger.String("k", "v"))),
+				expectedFields: []logger.Field{logger.String("k", "v")},
+			},
+			"error wrapped twice": {
+				err: logger.WrapError(
+					fmt.Errorf("wrapped: %w", logger.WrapError(ErrSome, logger.String("inner", "v"))),
+					logger.String("outer", "v"),
+				),
+				expectedFields: []logger.Field{logger.String("outer", "v"), logger.String("inner", "v")},
+			},
+			"joined errors": {
+				err: errors.Join(
+					logger.WrapError(ErrSome, logger.String("k1", "v1")),
+					ErrAnother,
+					logger.WrapError(ErrAnother, logger.String("k2", "v2")),
+				),
+				expectedFields: []logger.Field{logger.String("k1", "v1"), logger.String("k2", "v2")},
+			},
+			"error wrapping multiple errors": {
+				err: fmt.Errorf("%w %w",
+					logger.WrapError(ErrSome, logger.String("k1", "v1")),
+					logger.WrapError(ErrAnother, logger.String("k2", "v2")),
+				),
+				expectedFields: []logger.Field{logger.String("k1", "v1"), logger.String("k2", "v2")},
+			},
+		}
+
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				fields := logger.FieldsFromError(test.err)
+				assert.Equal(t, test.expectedFields, fields)
+			})
+		}
+	})
+}
+
+func TestLogWithErrorFields(t *testing.T) {
+	type causeLogger interface {
+		ErrorCauseKV(ctx context.Context, msg string, cause error, keyValues ...interface{})
+	}
+
+	errWithFields := fmt.Errorf("wrapped: %w", logger.WrapError(ErrSome, logger.String("k1", "v1")))
+
+	loggers := map[string]func(adapter logger.Adapter) causeLogger{
+		"normal": func(adapter logger.Adapter) causeLogger {
+			return logger.WithAdapter(adapter)
+		},
+		"global": func(adapter logger.Adapter) causeLogger {
+			var global logger.Global
+			global.SetAdapter(adapter)
+
+			return &global
+		},
+	}
+
+	for name, newLogger := range loggers {
+		t.Run(name, func(t *testing.T) {
+			t.Run("should add fields from cause", func(t *testing.T) {
+				adapter := &adapterMock{}
+				log := newLogger(adapter)
+				// when
+				log.ErrorCauseKV(ctx, message, errWithFields, "k2", "v2")
+				// then
+				adapter.HasExactlyOneEntryWithFields(t, []logger.Field{
+					logger.String("k1", "v1"),
+					{Key: "k2", Value: "v2"},
+				})
+				adapter.HasExactlyOneEntryWithError(t, errWithFields)
+			})
+		})
+	}
+
+	t.Run("should add fields from error passed to WithError", func(t *testing.T) {
+		adapter := &adapterMock{}
+		log := logger.WithAdapter(adapter).WithError(errWithFields)
+		// when
+		log.Error(ctx, message)
+		// then
+		adapter.HasExactlyOneEntryWithFields(t, []logger.Field{logger.String("k1", "v1")})
+	})
+}
+
+type formattedError struct{}
+
+func (formattedError) Error() string {
+	return "formatted error"
+}
+
+func (formattedError) Format(state fmt.State, verb rune) {
+	flag := ""
+	if state.Flag('+') {
+		flag = "+"
+	}
+
+	_, _ = fmt.Fprintf(state, "formatted error %s%c", flag, verb)
+}
+
+type typedError struct{}
+
+func (typedError) Error() string {
+	return "typed error"
+}
+
+func (typedError) ErrorType() string {
+	return "original.Type"
+}
+
+func TestErrorType(t *testing.T) {
+	tests := map[string]struct {
+		err          error
+		expectedType string
+	}{
+		"nil": {
+			err:          nil,
+			expectedType: "",
+		},
+		"error": {
+			err:          ErrSome,
+			expectedType: "*errors.errorString",
+		},
+		"error wrapped using fmt.Errorf": {
+			err:          fmt.Errorf("wrapped: %w", ErrSome),
+			expectedType: "*errors.errorString",
+		},
+		"error wrapped multiple times": {
+			err:          fmt.Errorf("wrapped: %w", logger.WrapError(fmt.Errorf("wrapped: %w", ErrSome))),
+			expectedType: "*errors.errorString",
+		},
+		"error wrapped using WrapError": {
+			err:          logger.WrapError(logger.WrapError(ErrSome)),
+			expectedType: "*errors.errorString",
+		},
+		"joined errors": {
+			err:          errors.Join(ErrSome, ErrAnother),
+			expectedType: "*errors.joinError",
+		},
+		"error with ErrorType method": {
+			err:          fmt.Errorf("wrapped: %w", typedError{}),
+			expectedType: "original.Type",
+		},
+		"wrapped joined errors": {
+			err:          fmt.Errorf("wrapped: %w", errors.Join(ErrSome, ErrAnother)),
+			expectedType: "*errors.joinError",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expectedType, logger.ErrorType(test.err))
+		})
+	}
+}
+
+func TestErrorCauses(t *testing.T) {
+	t.Run("should return nil for error not joining multiple errors", func(t *testing.T) {
+		assert.Nil(t, logger.ErrorCauses(nil))
+		assert.Nil(t, logger.ErrorCauses(ErrSome))
+		assert.Nil(t, logger.ErrorCauses(fmt.Errorf("wrapped: %w", ErrSome)))
+	})
+
+	t.Run("should return joined errors", func(t *testing.T) {
+		tests := map[string]error{
+			"errors.Join":                   errors.Join(ErrSome, ErrAnother),
+			"fmt.Errorf with multiple %w":   fmt.Errorf("%w %w", ErrSome, ErrAnother),
+			"joined errors wrapped":         fmt.Errorf("wrapped: %w", errors.Join(ErrSome, ErrAnother)),
+			"joined errors wrapped by yala": logger.WrapError(errors.Join(ErrSome, ErrAnother)),
+		}
+
+		for name, err := range tests {
+			t.Run(name, func(t *testing.T) {
+				assert.Equal(t, []error{ErrSome, ErrAnother}, logger.ErrorCauses(err))
+			})
+		}
+	})
+}
@@ -0,0 +1,12 @@
+// (c) 2022 Jacek Olszak
+// This code is licensed under MIT license (see LICENSE for details)
+
+package logger
+
+// ResetRegistry removes all registered Global loggers.
+func ResetRegistry() {
+	registry.Lock()
+	defer registry.Unlock()
+
+	registry.globals = nil
+}
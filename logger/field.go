@@ -0,0 +1,263 @@
+// (c) 2022 Jacek Olszak
+// This code is licensed under MIT license (see LICENSE for details)
+
+package logger
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+	"unsafe"
+)
+
+// Field contains key-value pair.
+//
+// Field can be created using a struct literal, for example Field{Key: "k", Value: v}, or using one of typed
+// constructors, such as String, Int64 or Bool. Typed constructors do not box the value into interface{}, so they do not
+// allocate memory. Such value is not available in Value though, therefore Field must always be copied as a whole.
+// Adapters should check the Kind first and then use the typed accessor (such as Int64Value) or AnyValue.
+type Field struct {
+	Key string
+	// Value is the value of field with KindAny (for example created with struct literal) or KindError.
+	// For other kinds Value is nil.
+	Value interface{}
+
+	num  uint64         // number, length of string or nanoseconds since Unix epoch
+	ptr  unsafe.Pointer // data of string, *time.Location or *time.Time
+	kind Kind
+}
+
+// Kind is a kind of Field value.
+type Kind uint8
+
+const (
+	// KindAny is a kind of value stored in Field.Value. Fields created with struct literal or Any have this kind.
+	KindAny Kind = iota
+	// KindString is a kind of field created with String.
+	KindString
+	// KindInt64 is a kind of field created with Int or Int64.
+	KindInt64
+	// KindUint64 is a kind of field created with Uint64.
+	KindUint64
+	// KindFloat64 is a kind of field created with Float64.
+	KindFloat64
+	// KindBool is a kind of field created with Bool.
+	KindBool
+	// KindDuration is a kind of field created with Duration.
+	KindDuration
+	// KindTime is a kind of field created with Time.
+	KindTime
+	// KindError is a kind of field created with Err. The error is stored in Field.Value.
+	KindError
+	// KindGroup is a kind of field created with Group. Such field has no value.
+	KindGroup
+)
+
+// kindTimePtr is an internal kind of field created with Time, which value cannot be stored as nanoseconds since Unix
+// epoch. Such value is stored in ptr as *time.Time. Kind returns KindTime for it.
+const kindTimePtr Kind = 255
+
+var kindNames = []string{"Any", "String", "Int64", "Uint64", "Float64", "Bool", "Duration", "Time", "Error", "Group"}
+
+// String converts the Kind to a string. For example KindInt64 becomes "Int64".
+func (k Kind) String() string {
+	if int(k) < len(kindNames) {
+		return kindNames[k]
+	}
+
+	return "Kind(" + strconv.Itoa(int(k)) + ")"
+}
+
+// Any creates a field with KindAny. The value is stored in Field.Value.
+func Any(key string, value interface{}) Field {
+	return Field{Key: key, Value: value}
+}
+
+// String creates a field with KindString.
+func String(key, value string) Field {
+	return Field{Key: key, kind: KindString, num: uint64(len(value)), ptr: unsafe.Pointer(unsafe.StringData(value))}
+}
+
+// Int creates a field with KindInt64.
+func Int(key string, value int) Field {
+	return Int64(key, int64(value))
+}
+
+// Int64 creates a field with KindInt64.
+func Int64(key string, value int64) Field {
+	return Field{Key: key, kind: KindInt64, num: uint64(value)}
+}
+
+// Uint64 creates a field with KindUint64.
+func Uint64(key string, value uint64) Field {
+	return Field{Key: key, kind: KindUint64, num: value}
+}
+
+// Float64 creates a field with KindFloat64.
+func Float64(key string, value float64) Field {
+	return Field{Key: key, kind: KindFloat64, num: math.Float64bits(value)}
+}
+
+// Bool creates a field with KindBool.
+func Bool(key string, value bool) Field {
+	var num uint64
+	if value {
+		num = 1
+	}
+
+	return Field{Key: key, kind: KindBool, num: num}
+}
+
+// Duration creates a field with KindDuration.
+func Duration(key string, value time.Duration) Field {
+	return Field{Key: key, kind: KindDuration, num: uint64(value)}
+}
+
+// minTime and maxTime are bounds of time which can be stored as nanoseconds since Unix epoch in int64.
+var (
+	minTime = time.Unix(0, math.MinInt64)
+	maxTime = time.Unix(0, math.MaxInt64)
+)
+
+// Time creates a field with KindTime. Monotonic clock reading is discarded.
+func Time(key string, value time.Time) Field {
+	if value.IsZero() && value.Location() == time.UTC {
+		return Field{Key: key, kind: KindTime} // nil ptr means zero time
+	}
+
+	if value.Before(minTime) || value.After(maxTime) {
+		// such time cannot be stored as nanoseconds, so it has to be stored in a pointer
+		boxed := value.Round(0)
+
+		return Field{Key: key, kind: kindTimePtr, ptr: unsafe.Pointer(&boxed)}
+	}
+
+	return Field{Key: key, kind: KindTime, num: uint64(value.UnixNano()), ptr: unsafe.Pointer(value.Location())}
+}
+
+// Err creates a field with KindError. The error is stored in Field.Value. If err is nil, the field has KindAny.
+func Err(key string, err error) Field {
+	if err == nil {
+		return Field{Key: key}
+	}
+
+	return Field{Key: key, kind: KindError, Value: err}
+}
+
+// Group creates a field with KindGroup, which opens a group (namespace) named after the key. All subsequent fields of
+// the entry are nested in this group. Groups can be nested too. For example, fields:
+//
+//	logger.Group("http"), logger.Int("status", 200)
+//
+// are logged by logfmt-based adapters as "http.status=200".
+//
+// Please note that group cannot be closed. Usually groups are created using Logger.WithGroup or Global.WithGroup.
+func Group(key string) Field {
+	return Field{Key: key, kind: KindGroup}
+}
+
+// Kind returns the kind of field value.
+func (f Field) Kind() Kind {
+	if f.kind == kindTimePtr {
+		return KindTime
+	}
+
+	return f.kind
+}
+
+// StringValue returns the value of field with KindString. It panics for other kinds.
+func (f Field) StringValue() string {
+	f.mustBe(KindString)
+
+	return unsafe.String((*byte)(f.ptr), int(f.num))
+}
+
+// Int64Value returns the value of field with KindInt64. It panics for other kinds.
+func (f Field) Int64Value() int64 {
+	f.mustBe(KindInt64)
+
+	return int64(f.num)
+}
+
+// Uint64Value returns the value of field with KindUint64. It panics for other kinds.
+func (f Field) Uint64Value() uint64 {
+	f.mustBe(KindUint64)
+
+	return f.num
+}
+
+// Float64Value returns the value of field with KindFloat64. It panics for other kinds.
+func (f Field) Float64Value() float64 {
+	f.mustBe(KindFloat64)
+
+	return math.Float64frombits(f.num)
+}
+
+// BoolValue returns the value of field with KindBool. It panics for other kinds.
+func (f Field) BoolValue() bool {
+	f.mustBe(KindBool)
+
+	return f.num == 1
+}
+
+// DurationValue returns the value of field with KindDuration. It panics for other kinds.
+func (f Field) DurationValue() time.Duration {
+	f.mustBe(KindDuration)
+
+	return time.Duration(f.num)
+}
+
+// TimeValue returns the value of field with KindTime. It panics for other kinds.
+func (f Field) TimeValue() time.Time {
+	f.mustBe(KindTime)
+
+	switch {
+	case f.kind == kindTimePtr:
+		return *(*time.Time)(f.ptr)
+	case f.ptr == nil:
+		return time.Time{}
+	default:
+		return time.Unix(0, int64(f.num)).In((*time.Location)(f.ptr))
+	}
+}
+
+// ErrorValue returns the value of field with KindError. It panics for other kinds.
+func (f Field) ErrorValue() error {
+	f.mustBe(KindError)
+
+	err, _ := f.Value.(error)
+
+	return err
+}
+
+// AnyValue returns the value of field with any kind. Values of typed fields are boxed into interface{}. For KindGroup
+// it returns nil.
+func (f Field) AnyValue() interface{} {
+	switch f.Kind() {
+	case KindString:
+		return f.StringValue()
+	case KindInt64:
+		return f.Int64Value()
+	case KindUint64:
+		return f.Uint64Value()
+	case KindFloat64:
+		return f.Float64Value()
+	case KindBool:
+		return f.BoolValue()
+	case KindDuration:
+		return f.DurationValue()
+	case KindTime:
+		return f.TimeValue()
+	case KindAny, KindError, KindGroup:
+		return f.Value
+	default:
+		return f.Value
+	}
+}
+
+func (f Field) mustBe(kind Kind) {
+	if actual := f.Kind(); actual != kind {
+		panic(fmt.Sprintf("field %s has kind %s, not %s", f.Key, actual, kind))
+	}
+}
@@ -0,0 +1,178 @@
+// (c) 2022 Jacek Olszak
+// This code is licensed under MIT license (see LICENSE for details)
+
+package logger_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/elgopher/yala/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypedFields(t *testing.T) {
+	t.Run("should create typed field", func(t *testing.T) {
+		tests := map[string]struct {
+			field         logger.Field
+			expectedKind  logger.Kind
+			expectedValue interface{}
+			typedValue    func(logger.Field) interface{}
+		}{
+			"String": {
+				field:         logger.String("k", "v"),
+				expectedKind:  logger.KindString,
+				expectedValue: "v",
+				typedValue:    func(f logger.Field) interface{} { return f.StringValue() },
+			},
+			"Int": {
+				field:         logger.Int("k", -1),
+				expectedKind:  logger.KindInt64,
+				expectedValue: int64(-1),
+				typedValue:    func(f logger.Field) interface{} { return f.Int64Value() },
+			},
+			"Int64": {
+				field:         logger.Int64("k", math.MinInt64),
+				expectedKind:  logger.KindInt64,
+				expectedValue: int64(math.MinInt64),
+				typedValue:    func(f logger.Field) interface{} { return f.Int64Value() },
+			},
+			"Uint64": {
+				field:         logger.Uint64("k", math.MaxUint64),
+				expectedKind:  logger.KindUint64,
+				expectedValue: uint64(math.MaxUint64),
+				typedValue:    func(f logger.Field) interface{} { return f.Uint64Value() },
+			},
+			"Float64": {
+				field:         logger.Float64("k", -1.5),
+				expectedKind:  logger.KindFloat64,
+				expectedValue: -1.5,
+				typedValue:    func(f logger.Field) interface{} { return f.Float64Value() },
+			},
+			"Bool true": {
+				field:         logger.Bool("k", true),
+				expectedKind:  logger.KindBool,
+				expectedValue: true,
+				typedValue:    func(f logger.Field) interface{} { return f.BoolValue() },
+			},
+			"Bool false": {
+				field:         logger.Bool("k", false),
+				expectedKind:  logger.KindBool,
+				expectedValue: false,
+				typedValue:    func(f logger.Field) interface{} { return f.BoolValue() },
+			},
+			"Duration": {
+				field:         logger.Duration("k", time.Second),
+				expectedKind:  logger.KindDuration,
+				expectedValue: time.Second,
+				typedValue:    func(f logger.Field) interface{} { return f.DurationValue() },
+			},
+			"Err": {
+				field:         logger.Err("k", ErrSome),
+				expectedKind:  logger.KindError,
+				expectedValue: ErrSome,
+				typedValue:    func(f logger.Field) interface{} { return f.ErrorValue() },
+			},
+			"Any": {
+				field:         logger.Any("k", []int{1}),
+				expectedKind:  logger.KindAny,
+				expectedValue: []int{1},
+				typedValue:    func(f logger.Field) interface{} { return f.Value },
+			},
+		}
+
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				assert.Equal(t, "k", test.field.Key)
+				assert.Equal(t, test.expectedKind, test.field.Kind())
+				assert.Equal(t, test.expectedValue, test.typedValue(test.field))
+				assert.Equal(t, test.expectedValue, test.field.AnyValue())
+			})
+		}
+	})
+
+	t.Run("should create Time field", func(t *testing.T) {
+		location := time.FixedZone("zone", 3600)
+
+		times := map[string]time.Time{
+			"zero":                time.Time{},
+			"UTC":                 time.Unix(1000, 1).UTC(),
+			"local":               time.Unix(1000, 1),
+			"fixed zone":          time.Unix(1000, 1).In(location),
+			"far future":          time.Date(3000, 1, 1, 0, 0, 0, 0, location),
+			"with monotonic time": time.Now(),
+		}
+
+		for name, expected := range times {
+			t.Run(name, func(t *testing.T) {
+				field := logger.Time("k", expected)
+				assert.Equal(t, logger.KindTime, field.Kind())
+				actual := field.TimeValue()
+				assert.True(t, expected.Equal(actual), "expected %s, got %s", expected, actual)
+				assert.Equal(t, expected.Location(), actual.Location())
+			})
+		}
+	})
+
+	t.Run("should create Err field with KindAny for nil error", func(t *testing.T) {
+		field := logger.Err("k", nil)
+		assert.Equal(t, logger.KindAny, field.Kind())
+		assert.Nil(t, field.AnyValue())
+	})
+
+	t.Run("should create field with KindAny using struct literal", func(t *testing.T) {
+		field := logger.Field{Key: "k", Value: "v"}
+		assert.Equal(t, logger.KindAny, field.Kind())
+		assert.Equal(t, "v", field.AnyValue())
+	})
+
+	t.Run("should not allocate memory when typed field is created", func(t *testing.T) {
+		now := time.Now()
+
+		newFields := map[string]func() logger.Field{
+			"String":    func() logger.Field { return logger.String("k", "v") },
+			"Int64":     func() logger.Field { return logger.Int64("k", math.MaxInt64) },
+			"Uint64":    func() logger.Field { return logger.Uint64("k", math.MaxUint64) },
+			"Float64":   func() logger.Field { return logger.Float64("k", 1.5) },
+			"Bool":      func() logger.Field { return logger.Bool("k", true) },
+			"Duration":  func() logger.Field { return logger.Duration("k", time.Second) },
+			"Time":      func() logger.Field { return logger.Time("k", now) },
+			"zero Time": func() logger.Field { return logger.Time("k", time.Time{}) },
+			"Err":       func() logger.Field { return logger.Err("k", ErrSome) },
+		}
+
+		for name, newField := range newFields {
+			t.Run(name, func(t *testing.T) {
+				allocs := testing.AllocsPerRun(100, func() {
+					field = newField()
+				})
+				assert.Zero(t, allocs)
+			})
+		}
+	})
+
+	t.Run("should create Group field", func(t *testing.T) {
+		field := logger.Group("g")
+		assert.Equal(t, "g", field.Key)
+		assert.Equal(t, logger.KindGroup, field.Kind())
+		assert.Nil(t, field.AnyValue())
+	})
+
+	t.Run("typed accessor should panic for field with different kind", func(t *testing.T) {
+		field := logger.String("k", "v")
+		assert.Panics(t, func() {
+			field.Int64Value()
+		})
+	})
+}
+
+var field logger.Field // global variable prevents compiler optimizations in tests and benchmarks
+
+func TestKind_String(t *testing.T) {
+	assert.Equal(t, "Any", logger.KindAny.String())
+	assert.Equal(t, "String", logger.KindString.String())
+	assert.Equal(t, "Error", logger.KindError.String())
+	assert.Equal(t, "Group", logger.KindGroup.String())
+	assert.Equal(t, "Kind(100)", logger.Kind(100).String())
+}
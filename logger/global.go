@@ -6,6 +6,7 @@ package logger
 import (
 	"context"
 	"sync/atomic"
+	"time"
 )
 
 // Global is a logger shared globally. You can use it to define global logger for your package:
@@ -25,38 +26,55 @@ import (
 // and WithError methods. These methods will create *logger.Global using shared adapter.
 type Global struct {
 	entry       Entry
-	adapter     atomic.Value  // not used when logger is a child
-	rootAdapter *atomic.Value // not nil, if logger is a child
+	adapter     atomic.Value     // not used when logger is a child
+	rootAdapter *atomic.Value    // not nil, if logger is a child
+	now         func() time.Time // nil means time.Now
 }
 
 type adapterWrapper struct{ Adapter } // stored in atomic.Value
 
-// SetAdapter updates adapter implementation. By default, nothing is logged.
+// SetAdapter updates adapter implementation. By default, nothing is logged (see SetUnconfiguredPolicy).
 //
 // It can be run anytime. Please note though that this method is meant to be used by end user, configuring logging
 // from the central place (such as main.go or any other package setting up the entire application).
 //
 // If this method is called on an instance created using With* methods, then all parent and child loggers
 // are updated too.
+//
+// When buffering of early entries is enabled (see BufferEarlyEntries), the first call passes all buffered entries
+// to the adapter.
 func (g *Global) SetAdapter(adapter Adapter) {
 	if adapter == nil {
 		adapter = noopAdapter{}
 	}
 
-	g.adapterValue().Store(adapterWrapper{Adapter: adapter})
+	previous := g.adapterValue().Swap(adapterWrapper{Adapter: adapter})
+	if wrapper, ok := previous.(adapterWrapper); ok {
+		if buffer, isBuffer := wrapper.Adapter.(*earlyEntriesBuffer); isBuffer {
+			buffer.replay(adapter)
+		}
+	}
 }
 
 func (g *Global) getAdapter() Adapter { //nolint:ireturn
 	value := g.adapterValue()
 
-	adapter, ok := value.Load().(Adapter)
+	wrapper, ok := value.Load().(adapterWrapper)
 	if !ok {
-		value.CompareAndSwap(nil, adapterWrapper{Adapter: &initialGlobalNoopAdapter{}})
+		value.CompareAndSwap(nil, adapterWrapper{Adapter: initialGlobalAdapter()})
 
 		return g.getAdapter()
 	}
 
-	return adapter
+	return wrapper.Adapter
+}
+
+func initialGlobalAdapter() Adapter { //nolint:ireturn
+	if config := earlyEntriesConfig(); config.Size > 0 {
+		return newEarlyEntriesBuffer(config)
+	}
+
+	return &unconfiguredAdapter{}
 }
 
 func (g *Global) adapterValue() *atomic.Value {
@@ -69,62 +87,121 @@ func (g *Global) adapterValue() *atomic.Value {
 
 // Debug logs a message at DebugLevel.
 func (g *Global) Debug(ctx context.Context, msg string) {
-	g.log(ctx, DebugLevel, msg, g.entry.Error, nil)
+	g.log(ctx, DebugLevel, msg, g.entry.Error, nil, nil)
 }
 
 // DebugFields logs a message at DebugLevel with fields.
 func (g *Global) DebugFields(ctx context.Context, msg string, fields Fields) {
-	g.log(ctx, DebugLevel, msg, g.entry.Error, fields)
+	g.log(ctx, DebugLevel, msg, g.entry.Error, fields, nil)
 }
 
-func (g *Global) log(ctx context.Context, level Level, msg string, cause error, fields Fields) {
-	newEntry := g.entry.WithFields(fields)
+// DebugKV logs a message at DebugLevel with fields given as alternating keys and values. See Entry.WithKeyValues.
+func (g *Global) DebugKV(ctx context.Context, msg string, keyValues ...interface{}) {
+	g.log(ctx, DebugLevel, msg, g.entry.Error, nil, keyValues)
+}
+
+func (g *Global) log(ctx context.Context, level Level, msg string, cause error, fields Fields, keyValues []interface{}) {
+	adapter := g.getAdapter()
+	if !Enabled(ctx, adapter, level) {
+		return
+	}
+
+	newEntry := g.entry.
+		withFieldsPrepended(FieldsFromContext(ctx), FieldsFromError(cause)).
+		WithFields(fields).
+		WithKeyValues(keyValues...)
 	newEntry.Level = level
 	newEntry.Message = msg
 	newEntry.Error = cause
+	newEntry.Time = now(g.now)
+	if ReportsCaller(adapter) {
+		newEntry.PC = callerPC(newEntry.SkippedCallerFrames)
+	}
+
 	newEntry.SkippedCallerFrames += 2
 
-	g.getAdapter().Log(ctx, newEntry)
+	adapter.Log(ctx, newEntry)
 }
 
 // Info logs a message at InfoLevel.
 func (g *Global) Info(ctx context.Context, msg string) {
-	g.log(ctx, InfoLevel, msg, g.entry.Error, nil)
+	g.log(ctx, InfoLevel, msg, g.entry.Error, nil, nil)
 }
 
 // InfoFields logs a message at InfoLevel with fields.
 func (g *Global) InfoFields(ctx context.Context, msg string, fields Fields) {
-	g.log(ctx, InfoLevel, msg, g.entry.Error, fields)
+	g.log(ctx, InfoLevel, msg, g.entry.Error, fields, nil)
+}
+
+// InfoKV logs a message at InfoLevel with fields given as alternating keys and values. See Entry.WithKeyValues.
+func (g *Global) InfoKV(ctx context.Context, msg string, keyValues ...interface{}) {
+	g.log(ctx, InfoLevel, msg, g.entry.Error, nil, keyValues)
 }
 
 // Warn logs a message at WarnLevel.
 func (g *Global) Warn(ctx context.Context, msg string) {
-	g.log(ctx, WarnLevel, msg, g.entry.Error, nil)
+	g.log(ctx, WarnLevel, msg, g.entry.Error, nil, nil)
 }
 
 // WarnFields logs a message at WarnLevel with fields.
 func (g *Global) WarnFields(ctx context.Context, msg string, fields Fields) {
-	g.log(ctx, WarnLevel, msg, g.entry.Error, fields)
+	g.log(ctx, WarnLevel, msg, g.entry.Error, fields, nil)
+}
+
+// WarnKV logs a message at WarnLevel with fields given as alternating keys and values. See Entry.WithKeyValues.
+func (g *Global) WarnKV(ctx context.Context, msg string, keyValues ...interface{}) {
+	g.log(ctx, WarnLevel, msg, g.entry.Error, nil, keyValues)
 }
 
 // Error logs a message at ErrorLevel.
 func (g *Global) Error(ctx context.Context, msg string) {
-	g.log(ctx, ErrorLevel, msg, g.entry.Error, nil)
+	g.log(ctx, ErrorLevel, msg, g.entry.Error, nil, nil)
 }
 
 // ErrorCause logs a message at ErrorLevel with cause.
 func (g *Global) ErrorCause(ctx context.Context, msg string, cause error) {
-	g.log(ctx, ErrorLevel, msg, cause, nil)
+	g.log(ctx, ErrorLevel, msg, cause, nil, nil)
 }
 
 // ErrorFields logs a message at ErrorLevel with fields.
 func (g *Global) ErrorFields(ctx context.Context, msg string, fields Fields) {
-	g.log(ctx, ErrorLevel, msg, g.entry.Error, fields)
+	g.log(ctx, ErrorLevel, msg, g.entry.Error, fields, nil)
 }
 
 // ErrorCauseFields logs a message at ErrorLevel with cause and fields.
 func (g *Global) ErrorCauseFields(ctx context.Context, msg string, cause error, fields Fields) {
-	g.log(ctx, ErrorLevel, msg, cause, fields)
+	g.log(ctx, ErrorLevel, msg, cause, fields, nil)
+}
+
+// ErrorKV logs a message at ErrorLevel with fields given as alternating keys and values. See Entry.WithKeyValues.
+func (g *Global) ErrorKV(ctx context.Context, msg string, keyValues ...interface{}) {
+	g.log(ctx, ErrorLevel, msg, g.entry.Error, nil, keyValues)
+}
+
+// ErrorCauseKV logs a message at ErrorLevel with cause and fields given as alternating keys and values.
+// See Entry.WithKeyValues.
+func (g *Global) ErrorCauseKV(ctx context.Context, msg string, cause error, keyValues ...interface{}) {
+	g.log(ctx, ErrorLevel, msg, cause, nil, keyValues)
+}
+
+// Enabled returns true if messages with given level will be logged. It can be used to skip expensive computation of
+// fields for messages which would be discarded anyway.
+func (g *Global) Enabled(ctx context.Context, level Level) bool {
+	return Enabled(ctx, g.getAdapter(), level)
+}
+
+// Sync writes all entries buffered by the adapter, if it implements Syncer. It should be called by the end user
+// before application exits, for example in main.go:
+//
+//	defer log.Sync()
+func (g *Global) Sync() error {
+	return Sync(g.getAdapter())
+}
+
+// Close writes all buffered entries and releases resources of the adapter, if it implements Closer. Nothing should
+// be logged after Close.
+func (g *Global) Close() error {
+	return Close(g.getAdapter())
 }
 
 // With creates a new child logger with additional field.
@@ -134,6 +211,7 @@ func (g *Global) With(key string, value interface{}) *Global {
 	return &Global{
 		entry:       newEntry,
 		rootAdapter: g.adapterValue(),
+		now:         g.now,
 	}
 }
 
@@ -144,6 +222,33 @@ func (g *Global) WithFields(fields Fields) *Global {
 	return &Global{
 		entry:       newEntry,
 		rootAdapter: g.adapterValue(),
+		now:         g.now,
+	}
+}
+
+// Named creates a new child logger with name appended to the logger name, separated with a dot. For example,
+// log.Named("payments").Named("db") creates a logger with "payments.db" name. Empty name is ignored.
+// See Entry.Name.
+func (g *Global) Named(name string) *Global {
+	return &Global{
+		entry:       g.entry.WithName(name),
+		rootAdapter: g.adapterValue(),
+		now:         g.now,
+	}
+}
+
+// WithGroup creates a new child logger which nests all subsequent fields in the group (namespace) with given name.
+// Fields added before are not nested. Groups can be nested too. Empty name is ignored. See Group.
+func (g *Global) WithGroup(name string) *Global {
+	newEntry := g.entry
+	if name != "" {
+		newEntry = newEntry.With(Group(name))
+	}
+
+	return &Global{
+		entry:       newEntry,
+		rootAdapter: g.adapterValue(),
+		now:         g.now,
 	}
 }
 
@@ -155,6 +260,7 @@ func (g *Global) WithError(err error) *Global {
 	return &Global{
 		entry:       newEntry,
 		rootAdapter: g.adapterValue(),
+		now:         g.now,
 	}
 }
 
@@ -167,5 +273,16 @@ func (g *Global) WithSkippedCallerFrame() *Global {
 	return &Global{
 		entry:       newEntry,
 		rootAdapter: g.adapterValue(),
+		now:         g.now,
+	}
+}
+
+// WithClock creates a new child logger which uses the given function to get the time of each entry. By default,
+// time.Now is used. This function is handy in tests.
+func (g *Global) WithClock(now func() time.Time) *Global {
+	return &Global{
+		entry:       g.entry,
+		rootAdapter: g.adapterValue(),
+		now:         now,
 	}
 }
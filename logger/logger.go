@@ -7,6 +7,8 @@ package logger
 
 import (
 	"context"
+	"runtime"
+	"time"
 )
 
 // Logger is an immutable logger to log messages or create new loggers with fields or error.
@@ -17,6 +19,7 @@ import (
 type Logger struct {
 	adapter Adapter
 	entry   Entry
+	now     func() time.Time // nil means time.Now
 }
 
 // WithAdapter creates a new Logger.
@@ -26,23 +29,36 @@ func WithAdapter(adapter Adapter) Logger {
 
 // Debug logs a message at DebugLevel.
 func (l Logger) Debug(ctx context.Context, msg string) {
-	l.log(ctx, DebugLevel, msg, l.entry.Error, nil)
+	l.log(ctx, DebugLevel, msg, l.entry.Error, nil, nil)
 }
 
 // DebugFields logs a message at DebugLevel with fields.
 func (l Logger) DebugFields(ctx context.Context, msg string, fields Fields) {
-	l.log(ctx, DebugLevel, msg, l.entry.Error, fields)
+	l.log(ctx, DebugLevel, msg, l.entry.Error, fields, nil)
 }
 
-func (l Logger) log(ctx context.Context, lvl Level, msg string, cause error, fields Fields) {
-	if l.adapter == nil {
+// DebugKV logs a message at DebugLevel with fields given as alternating keys and values. See Entry.WithKeyValues.
+func (l Logger) DebugKV(ctx context.Context, msg string, keyValues ...interface{}) {
+	l.log(ctx, DebugLevel, msg, l.entry.Error, nil, keyValues)
+}
+
+func (l Logger) log(ctx context.Context, lvl Level, msg string, cause error, fields Fields, keyValues []interface{}) {
+	if l.adapter == nil || !Enabled(ctx, l.adapter, lvl) {
 		return
 	}
 
-	newEntry := l.entry.WithFields(fields)
+	newEntry := l.entry.
+		withFieldsPrepended(FieldsFromContext(ctx), FieldsFromError(cause)).
+		WithFields(fields).
+		WithKeyValues(keyValues...)
 	newEntry.Error = cause
 	newEntry.Level = lvl
 	newEntry.Message = msg
+	newEntry.Time = now(l.now)
+	if ReportsCaller(l.adapter) {
+		newEntry.PC = callerPC(newEntry.SkippedCallerFrames)
+	}
+
 	newEntry.SkippedCallerFrames += 2
 
 	l.adapter.Log(ctx, newEntry)
@@ -50,47 +66,74 @@ func (l Logger) log(ctx context.Context, lvl Level, msg string, cause error, fie
 
 // Info logs a message at InfoLevel.
 func (l Logger) Info(ctx context.Context, msg string) {
-	l.log(ctx, InfoLevel, msg, l.entry.Error, nil)
+	l.log(ctx, InfoLevel, msg, l.entry.Error, nil, nil)
 }
 
 // InfoFields logs a message at InfoLevel with fields.
 func (l Logger) InfoFields(ctx context.Context, msg string, fields Fields) {
-	l.log(ctx, InfoLevel, msg, l.entry.Error, fields)
+	l.log(ctx, InfoLevel, msg, l.entry.Error, fields, nil)
+}
+
+// InfoKV logs a message at InfoLevel with fields given as alternating keys and values. See Entry.WithKeyValues.
+func (l Logger) InfoKV(ctx context.Context, msg string, keyValues ...interface{}) {
+	l.log(ctx, InfoLevel, msg, l.entry.Error, nil, keyValues)
 }
 
 // Warn logs a message at WarnLevel.
 func (l Logger) Warn(ctx context.Context, msg string) {
-	l.log(ctx, WarnLevel, msg, l.entry.Error, nil)
+	l.log(ctx, WarnLevel, msg, l.entry.Error, nil, nil)
 }
 
 // WarnFields logs a message at WarnLevel with fields.
 func (l Logger) WarnFields(ctx context.Context, msg string, fields Fields) {
-	l.log(ctx, WarnLevel, msg, l.entry.Error, fields)
+	l.log(ctx, WarnLevel, msg, l.entry.Error, fields, nil)
+}
+
+// WarnKV logs a message at WarnLevel with fields given as alternating keys and values. See Entry.WithKeyValues.
+func (l Logger) WarnKV(ctx context.Context, msg string, keyValues ...interface{}) {
+	l.log(ctx, WarnLevel, msg, l.entry.Error, nil, keyValues)
 }
 
 // Error logs a message at ErrorLevel.
 func (l Logger) Error(ctx context.Context, msg string) {
-	l.log(ctx, ErrorLevel, msg, l.entry.Error, nil)
+	l.log(ctx, ErrorLevel, msg, l.entry.Error, nil, nil)
 }
 
 // ErrorCause logs a message at ErrorLevel with cause.
 func (l Logger) ErrorCause(ctx context.Context, msg string, cause error) {
-	l.log(ctx, ErrorLevel, msg, cause, nil)
+	l.log(ctx, ErrorLevel, msg, cause, nil, nil)
 }
 
 // ErrorFields logs a message at ErrorLevel with fields.
 func (l Logger) ErrorFields(ctx context.Context, msg string, fields Fields) {
-	l.log(ctx, ErrorLevel, msg, l.entry.Error, fields)
+	l.log(ctx, ErrorLevel, msg, l.entry.Error, fields, nil)
 }
 
 // ErrorCauseFields logs a message at ErrorLevel with cause and fields.
 func (l Logger) ErrorCauseFields(ctx context.Context, msg string, cause error, fields Fields) {
-	l.log(ctx, ErrorLevel, msg, cause, fields)
+	l.log(ctx, ErrorLevel, msg, cause, fields, nil)
+}
+
+// ErrorKV logs a message at ErrorLevel with fields given as alternating keys and values. See Entry.WithKeyValues.
+func (l Logger) ErrorKV(ctx context.Context, msg string, keyValues ...interface{}) {
+	l.log(ctx, ErrorLevel, msg, l.entry.Error, nil, keyValues)
+}
+
+// ErrorCauseKV logs a message at ErrorLevel with cause and fields given as alternating keys and values.
+// See Entry.WithKeyValues.
+func (l Logger) ErrorCauseKV(ctx context.Context, msg string, cause error, keyValues ...interface{}) {
+	l.log(ctx, ErrorLevel, msg, cause, nil, keyValues)
+}
+
+// Enabled returns true if messages with given level will be logged. It can be used to skip expensive computation of
+// fields for messages which would be discarded anyway.
+func (l Logger) Enabled(ctx context.Context, level Level) bool {
+	return l.adapter != nil && Enabled(ctx, l.adapter, level)
 }
 
 // With creates a new logger with additional field.
 func (l Logger) With(key string, value interface{}) Logger {
-	l.entry = l.entry.With(Field{key, value})
+	l.entry = l.entry.With(Field{Key: key, Value: value})
 
 	return l
 }
@@ -102,6 +145,27 @@ func (l Logger) WithFields(fields Fields) Logger {
 	return l
 }
 
+// Named creates a new logger with name appended to the logger name, separated with a dot. For example,
+// log.Named("payments").Named("db") creates a logger with "payments.db" name. Empty name is ignored.
+// See Entry.Name.
+func (l Logger) Named(name string) Logger {
+	l.entry = l.entry.WithName(name)
+
+	return l
+}
+
+// WithGroup creates a new logger which nests all subsequent fields in the group (namespace) with given name.
+// Fields added before are not nested. Groups can be nested too. Empty name is ignored. See Group.
+func (l Logger) WithGroup(name string) Logger {
+	if name == "" {
+		return l
+	}
+
+	l.entry = l.entry.With(Group(name))
+
+	return l
+}
+
 // WithError creates a new logger with error.
 func (l Logger) WithError(err error) Logger {
 	l.entry.Error = err
@@ -116,3 +180,29 @@ func (l Logger) WithSkippedCallerFrame() Logger {
 
 	return l
 }
+
+// WithClock creates a new logger which uses the given function to get the time of each entry. By default, time.Now
+// is used. This function is handy in tests.
+func (l Logger) WithClock(now func() time.Time) Logger {
+	l.now = now
+
+	return l
+}
+
+func now(clock func() time.Time) time.Time {
+	if clock == nil {
+		return time.Now()
+	}
+
+	return clock()
+}
+
+func callerPC(skippedCallerFrames int) uintptr {
+	var pcs [1]uintptr
+
+	const skip = 4 // runtime.Callers, callerPC, log and the logging method (such as Info)
+
+	runtime.Callers(skip+skippedCallerFrames, pcs[:])
+
+	return pcs[0]
+}
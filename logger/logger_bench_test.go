@@ -6,6 +6,7 @@ package logger_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/elgopher/yala/logger"
 )
@@ -72,6 +73,19 @@ func BenchmarkWithError(b *testing.B) {
 	}
 }
 
+func BenchmarkGlobal_Info(b *testing.B) {
+	var log logger.Global
+
+	log.SetAdapter(discardAdapter{})
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		log.Info(ctx, message) // PC is not captured, because adapter does not implement logger.CallerReporter
+	}
+}
+
 func BenchmarkGlobal_InfoFields(b *testing.B) {
 	var log logger.Global
 
@@ -89,6 +103,22 @@ func BenchmarkGlobal_InfoFields(b *testing.B) {
 	}
 }
 
+func BenchmarkTypedFields(b *testing.B) {
+	now := time.Now()
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		field = logger.String("k", "v")
+		field = logger.Int64("k", int64(i))
+		field = logger.Float64("k", float64(i))
+		field = logger.Bool("k", true)
+		field = logger.Duration("k", time.Second)
+		field = logger.Time("k", now)
+		field = logger.Err("k", ErrSome) // 40ns for all fields, 0 allocs
+	}
+}
+
 type discardAdapter struct{}
 
 func (d discardAdapter) Log(context.Context, logger.Entry) {}
@@ -6,7 +6,10 @@ package logger_test
 import (
 	"context"
 	"errors"
+	"runtime"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/elgopher/yala/logger"
 	"github.com/stretchr/testify/assert"
@@ -209,7 +212,7 @@ func TestLogFields(t *testing.T) {
 						// then
 						adapter.HasExactlyOneEntry(t, logger.Entry{
 							Level:               lvl,
-							Fields:              []logger.Field{{"k", "v"}},
+							Fields:              []logger.Field{{Key: "k", Value: "v"}},
 							Message:             message,
 							SkippedCallerFrames: 2,
 						})
@@ -226,8 +229,8 @@ func TestLogFields(t *testing.T) {
 						// then
 						assert.ElementsMatch(t,
 							[]logger.Field{
-								{"k1", "v1"},
-								{"k2", "v2"},
+								{Key: "k1", Value: "v1"},
+								{Key: "k2", Value: "v2"},
 							},
 							adapter.entries[0].Fields)
 					})
@@ -246,10 +249,10 @@ func TestLogFields(t *testing.T) {
 						// then
 						assert.ElementsMatch(t,
 							[]logger.Field{
-								{"k1", "v1"},
-								{"k2", "v2"},
-								{"k3", "v3"},
-								{"k4", "v4"},
+								{Key: "k1", Value: "v1"},
+								{Key: "k2", Value: "v2"},
+								{Key: "k3", Value: "v3"},
+								{Key: "k4", Value: "v4"},
 							},
 							adapter.entries[0].Fields)
 					})
@@ -259,10 +262,75 @@ func TestLogFields(t *testing.T) {
 	}
 }
 
+func TestLogKV(t *testing.T) {
+	type kvLogger interface {
+		DebugKV(context.Context, string, ...interface{})
+		InfoKV(context.Context, string, ...interface{})
+		WarnKV(context.Context, string, ...interface{})
+		ErrorKV(context.Context, string, ...interface{})
+	}
+
+	loggers := map[string]func(adapter logger.Adapter) kvLogger{
+		"normal logger": func(adapter logger.Adapter) kvLogger {
+			return logger.WithAdapter(adapter).With("k0", "v0")
+		},
+		"global logger": func(adapter logger.Adapter) kvLogger {
+			var log logger.Global
+			log.SetAdapter(adapter)
+
+			return log.With("k0", "v0")
+		},
+	}
+
+	levelToMethodMapping := map[logger.Level]func(kvLogger, context.Context, string, ...interface{}){
+		logger.DebugLevel: kvLogger.DebugKV,
+		logger.InfoLevel:  kvLogger.InfoKV,
+		logger.WarnLevel:  kvLogger.WarnKV,
+		logger.ErrorLevel: kvLogger.ErrorKV,
+	}
+
+	for name, createLogger := range loggers {
+		t.Run(name, func(t *testing.T) {
+			for lvl, logKV := range levelToMethodMapping {
+				t.Run(lvl.String(), func(t *testing.T) {
+					t.Run("should log message with fields in given order", func(t *testing.T) {
+						adapter := &adapterMock{}
+						log := createLogger(adapter)
+						// when
+						logKV(log, ctx, message, "k2", "v2", "k1", "v1", "bad")
+						// then
+						adapter.HasExactlyOneEntry(t, logger.Entry{
+							Level:   lvl,
+							Message: message,
+							Fields: []logger.Field{
+								{Key: "k0", Value: "v0"},
+								{Key: "k2", Value: "v2"},
+								{Key: "k1", Value: "v1"},
+								{Key: logger.BadKey, Value: "bad"},
+							},
+							SkippedCallerFrames: 2,
+						})
+					})
+
+					t.Run("should log message without key values", func(t *testing.T) {
+						adapter := &adapterMock{}
+						log := createLogger(adapter)
+						// when
+						logKV(log, ctx, message)
+						// then
+						adapter.HasExactlyOneEntryWithFields(t, []logger.Field{{Key: "k0", Value: "v0"}})
+					})
+				})
+			}
+		})
+	}
+}
+
 func TestLogCause(t *testing.T) {
 	type errorLogger interface {
 		ErrorCause(context.Context, string, error)
 		ErrorCauseFields(context.Context, string, error, logger.Fields)
+		ErrorCauseKV(context.Context, string, error, ...interface{})
 	}
 
 	type newLogger func(adapter logger.Adapter, originalError error) errorLogger
@@ -303,7 +371,22 @@ func TestLogCause(t *testing.T) {
 				adapter.HasExactlyOneEntry(t, logger.Entry{
 					Level:               logger.ErrorLevel,
 					Message:             message,
-					Fields:              []logger.Field{{"k", "v"}},
+					Fields:              []logger.Field{{Key: "k", Value: "v"}},
+					Error:               ErrSome,
+					SkippedCallerFrames: 2,
+				})
+			})
+
+			t.Run("should log cause and key values", func(t *testing.T) {
+				adapter := &adapterMock{}
+				log := createNewLogger(adapter, nil)
+				// when
+				log.ErrorCauseKV(ctx, message, ErrSome, "k", "v")
+				// then
+				adapter.HasExactlyOneEntry(t, logger.Entry{
+					Level:               logger.ErrorLevel,
+					Message:             message,
+					Fields:              []logger.Field{{Key: "k", Value: "v"}},
 					Error:               ErrSome,
 					SkippedCallerFrames: 2,
 				})
@@ -313,6 +396,9 @@ func TestLogCause(t *testing.T) {
 				"ErrorCauseFields": func(errorLogger errorLogger, cause error) {
 					errorLogger.ErrorCauseFields(ctx, message, cause, logger.Fields{})
 				},
+				"ErrorCauseKV": func(errorLogger errorLogger, cause error) {
+					errorLogger.ErrorCauseKV(ctx, message, cause)
+				},
 				"ErrorCause": func(errorLogger errorLogger, cause error) {
 					errorLogger.ErrorCause(ctx, message, cause)
 				},
@@ -474,7 +560,7 @@ func TestLogger_WithFields(t *testing.T) {
 		newLogger.Info(ctx, message)
 		require.Len(t, adapter.entries, 1)
 		assert.ElementsMatch(t,
-			[]logger.Field{{"k1", "v1"}, {"k2", "v2"}},
+			[]logger.Field{{Key: "k1", Value: "v1"}, {Key: "k2", Value: "v2"}},
 			adapter.entries[0].Fields,
 		)
 	})
@@ -494,12 +580,154 @@ func TestGlobal_WithFields(t *testing.T) {
 		newLogger.Info(ctx, message)
 		require.Len(t, adapter.entries, 1)
 		assert.ElementsMatch(t,
-			[]logger.Field{{"k1", "v1"}, {"k2", "v2"}},
+			[]logger.Field{{Key: "k1", Value: "v1"}, {Key: "k2", Value: "v2"}},
 			adapter.entries[0].Fields,
 		)
 	})
 }
 
+func TestNamed(t *testing.T) {
+	tests := map[string]struct {
+		newLogger func(logger.Adapter) anyLogger
+		named     func(l anyLogger, name string) anyLogger
+	}{
+		"normal": {
+			newLogger: func(adapter logger.Adapter) anyLogger {
+				return logger.WithAdapter(adapter)
+			},
+			named: func(l anyLogger, name string) anyLogger {
+				return l.(logger.Logger).Named(name) //nolint:forcetypeassert // no generics still in Go
+			},
+		},
+		"global": {
+			newLogger: func(adapter logger.Adapter) anyLogger {
+				var global logger.Global
+				global.SetAdapter(adapter)
+
+				return &global
+			},
+			named: func(l anyLogger, name string) anyLogger {
+				return l.(*logger.Global).Named(name) //nolint:forcetypeassert // no generics still in Go
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Run("should log entry with name", func(t *testing.T) {
+				adapter := &adapterMock{}
+				log := test.newLogger(adapter)
+				// when
+				log = test.named(log, "payments")
+				// then
+				log.Info(ctx, message)
+				adapter.HasExactlyOneEntry(t, logger.Entry{
+					Level:               logger.InfoLevel,
+					Message:             message,
+					Name:                "payments",
+					SkippedCallerFrames: 2,
+				})
+			})
+
+			t.Run("should append names using dots", func(t *testing.T) {
+				adapter := &adapterMock{}
+				log := test.newLogger(adapter)
+				// when
+				log = test.named(test.named(test.named(log, "payments"), ""), "db")
+				// then
+				log.Info(ctx, message)
+				require.Len(t, adapter.entries, 1)
+				assert.Equal(t, "payments.db", adapter.entries[0].Name)
+			})
+
+			t.Run("should not change parent logger", func(t *testing.T) {
+				adapter := &adapterMock{}
+				log := test.newLogger(adapter)
+				// when
+				test.named(log, "payments")
+				// then
+				log.Info(ctx, message)
+				require.Len(t, adapter.entries, 1)
+				assert.Empty(t, adapter.entries[0].Name)
+			})
+		})
+	}
+}
+
+func TestWithGroup(t *testing.T) {
+	tests := map[string]struct {
+		newLogger func(logger.Adapter) anyLogger
+		with      func(l anyLogger, key string, value interface{}) anyLogger
+		withGroup func(l anyLogger, name string) anyLogger
+	}{
+		"normal": {
+			newLogger: func(adapter logger.Adapter) anyLogger {
+				return logger.WithAdapter(adapter)
+			},
+			with: func(l anyLogger, key string, value interface{}) anyLogger {
+				return l.(logger.Logger).With(key, value) //nolint:forcetypeassert // no generics still in Go
+			},
+			withGroup: func(l anyLogger, name string) anyLogger {
+				return l.(logger.Logger).WithGroup(name) //nolint:forcetypeassert // no generics still in Go
+			},
+		},
+		"global": {
+			newLogger: func(adapter logger.Adapter) anyLogger {
+				var global logger.Global
+				global.SetAdapter(adapter)
+
+				return &global
+			},
+			with: func(l anyLogger, key string, value interface{}) anyLogger {
+				return l.(*logger.Global).With(key, value) //nolint:forcetypeassert // no generics still in Go
+			},
+			withGroup: func(l anyLogger, name string) anyLogger {
+				return l.(*logger.Global).WithGroup(name) //nolint:forcetypeassert // no generics still in Go
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Run("should nest subsequent fields in group", func(t *testing.T) {
+				adapter := &adapterMock{}
+				log := test.newLogger(adapter)
+				log = test.with(log, "k1", "v1")
+				// when
+				log = test.withGroup(log, "g")
+				// then
+				log = test.with(log, "k2", "v2")
+				log.Info(ctx, message)
+				adapter.HasExactlyOneEntryWithFields(t, []logger.Field{
+					{Key: "k1", Value: "v1"},
+					logger.Group("g"),
+					{Key: "k2", Value: "v2"},
+				})
+			})
+
+			t.Run("should ignore empty group name", func(t *testing.T) {
+				adapter := &adapterMock{}
+				log := test.newLogger(adapter)
+				// when
+				log = test.withGroup(log, "")
+				// then
+				log.Info(ctx, message)
+				adapter.HasExactlyOneEntryWithFields(t, nil)
+			})
+
+			t.Run("should not modify parent logger", func(t *testing.T) {
+				adapter := &adapterMock{}
+				log := test.newLogger(adapter)
+				// when
+				_ = test.withGroup(log, "g")
+				// then
+				log.Info(ctx, message)
+				adapter.HasExactlyOneEntryWithFields(t, nil)
+			})
+		})
+	}
+}
+
 func TestWithError(t *testing.T) {
 	globalWithError := func(l anyLogger, err error) anyLogger {
 		return l.(*logger.Global).WithError(err) //nolint:forcetypeassert // no generics still in Go
@@ -654,6 +882,183 @@ func TestWithSkippedCallerFrame(t *testing.T) {
 	}
 }
 
+func TestWithClock(t *testing.T) {
+	fixedTime := time.Date(2022, 1, 2, 3, 4, 5, 6, time.UTC)
+	clock := func() time.Time { return fixedTime }
+
+	tests := map[string]struct {
+		newLogger func(logger.Adapter) anyLogger
+		withClock func(anyLogger) anyLogger
+	}{
+		"normal": {
+			newLogger: func(adapter logger.Adapter) anyLogger {
+				return logger.WithAdapter(adapter)
+			},
+			withClock: func(l anyLogger) anyLogger {
+				return l.(logger.Logger).WithClock(clock) //nolint:forcetypeassert // no generics still in Go
+			},
+		},
+		"global": {
+			newLogger: func(adapter logger.Adapter) anyLogger {
+				var global logger.Global
+				global.SetAdapter(adapter)
+
+				return &global
+			},
+			withClock: func(l anyLogger) anyLogger {
+				return l.(*logger.Global).WithClock(clock) //nolint:forcetypeassert // no generics still in Go
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Run("should use current time by default", func(t *testing.T) {
+				adapter := &adapterMock{}
+				log := test.newLogger(adapter)
+				before := time.Now()
+				// when
+				log.Info(ctx, message)
+				// then
+				after := time.Now()
+				require.Len(t, adapter.entries, 1)
+				entryTime := adapter.entries[0].Time
+				assert.False(t, entryTime.Before(before), "entry time %s is before %s", entryTime, before)
+				assert.False(t, entryTime.After(after), "entry time %s is after %s", entryTime, after)
+			})
+
+			t.Run("should use clock", func(t *testing.T) {
+				adapter := &adapterMock{}
+				log := test.newLogger(adapter)
+				// when
+				log = test.withClock(log)
+				// then
+				log.Info(ctx, message)
+				adapter.HasExactlyOneEntry(t, logger.Entry{
+					Level:               logger.InfoLevel,
+					Message:             message,
+					Time:                fixedTime,
+					SkippedCallerFrames: defaultSkippedCallerFrames(test.newLogger),
+				})
+			})
+
+			t.Run("child logger should use parent clock", func(t *testing.T) {
+				adapter := &adapterMock{}
+				log := test.withClock(test.newLogger(adapter))
+				// when
+				switch l := log.(type) {
+				case logger.Logger:
+					log = l.With("k", "v")
+				case *logger.Global:
+					log = l.With("k", "v")
+				}
+				// then
+				log.Info(ctx, message)
+				require.Len(t, adapter.entries, 1)
+				assert.Equal(t, fixedTime, adapter.entries[0].Time)
+			})
+		})
+	}
+}
+
+func TestCaller(t *testing.T) {
+	const expectedFunction = "github.com/elgopher/yala/logger_test.TestCaller."
+
+	loggers := map[string]func(adapter logger.Adapter) anyLogger{
+		"normal": func(adapter logger.Adapter) anyLogger {
+			return logger.WithAdapter(adapter)
+		},
+		"global": func(adapter logger.Adapter) anyLogger {
+			var global logger.Global
+			global.SetAdapter(adapter)
+
+			return &global
+		},
+	}
+
+	for loggerName, newLogger := range loggers {
+		t.Run(loggerName, func(t *testing.T) {
+			for methodName, logMessage := range loggerMethods {
+				t.Run(methodName, func(t *testing.T) {
+					adapter := &adapterMock{}
+					log := newLogger(adapter)
+					// when
+					logMessage(log, ctx, message)
+					// then
+					require.Len(t, adapter.entries, 1)
+					frame, ok := adapter.entries[0].Caller()
+					require.True(t, ok)
+					assert.Truef(t, strings.HasPrefix(frame.Function, expectedFunction),
+						"function %s has no prefix %s", frame.Function, expectedFunction)
+					assert.True(t, strings.HasSuffix(frame.File, "logger_test.go"))
+					assert.NotZero(t, frame.Line)
+				})
+			}
+		})
+	}
+
+	t.Run("should skip caller frame", func(t *testing.T) {
+		adapter := &adapterMock{}
+		log := logger.WithAdapter(adapter).WithSkippedCallerFrame()
+		logHelper := func() {
+			log.Info(ctx, message)
+		}
+		_, _, line, _ := runtime.Caller(0)
+		// when
+		logHelper()
+		// then
+		require.Len(t, adapter.entries, 1)
+		frame, ok := adapter.entries[0].Caller()
+		require.True(t, ok)
+		assert.Equal(t, line+2, frame.Line)
+	})
+}
+
+func TestReportsCaller(t *testing.T) {
+	t.Run("should return false when adapter does not implement CallerReporter", func(t *testing.T) {
+		assert.False(t, logger.ReportsCaller(&concurrencySafeAdapter{}))
+	})
+
+	t.Run("should return value returned by CallerReporter", func(t *testing.T) {
+		assert.True(t, logger.ReportsCaller(&adapterMock{}))
+	})
+
+	t.Run("should not capture PC when adapter does not report caller", func(t *testing.T) {
+		loggers := map[string]func(adapter logger.Adapter) anyLogger{
+			"normal": func(adapter logger.Adapter) anyLogger {
+				return logger.WithAdapter(adapter)
+			},
+			"global": func(adapter logger.Adapter) anyLogger {
+				var global logger.Global
+				global.SetAdapter(adapter)
+
+				return &global
+			},
+		}
+
+		for loggerName, newLogger := range loggers {
+			t.Run(loggerName, func(t *testing.T) {
+				adapter := &noCallerAdapterMock{}
+				log := newLogger(adapter)
+				// when
+				log.Info(ctx, message)
+				// then
+				require.Len(t, adapter.entries, 1)
+				assert.Zero(t, adapter.entries[0].PC)
+				assert.Equal(t, 2, adapter.entries[0].SkippedCallerFrames)
+			})
+		}
+	})
+}
+
+type noCallerAdapterMock struct {
+	entries []logger.Entry
+}
+
+func (a *noCallerAdapterMock) Log(_ context.Context, entry logger.Entry) {
+	a.entries = append(a.entries, entry)
+}
+
 func defaultSkippedCallerFrames(newLogger func(logger.Adapter) anyLogger) int {
 	adapter := &adapterMock{}
 	log := newLogger(adapter)
@@ -661,3 +1066,160 @@ func defaultSkippedCallerFrames(newLogger func(logger.Adapter) anyLogger) int {
 
 	return adapter.entries[0].SkippedCallerFrames
 }
+
+func TestEnabled(t *testing.T) {
+	type enabledLogger interface {
+		anyLogger
+		Enabled(context.Context, logger.Level) bool
+	}
+
+	loggers := map[string]func(adapter logger.Adapter) enabledLogger{
+		"normal": func(adapter logger.Adapter) enabledLogger {
+			return logger.WithAdapter(adapter)
+		},
+		"global": func(adapter logger.Adapter) enabledLogger {
+			var global logger.Global
+			global.SetAdapter(adapter)
+
+			return &global
+		},
+		"global child": func(adapter logger.Adapter) enabledLogger {
+			var global logger.Global
+			global.SetAdapter(adapter)
+
+			return global.With("k", "v")
+		},
+	}
+
+	for name, newLogger := range loggers {
+		t.Run(name, func(t *testing.T) {
+			t.Run("should return true when adapter does not implement LevelEnabler", func(t *testing.T) {
+				log := newLogger(&adapterMock{})
+				assert.True(t, log.Enabled(ctx, logger.DebugLevel))
+			})
+
+			t.Run("should return false for nil adapter", func(t *testing.T) {
+				log := newLogger(nil)
+				assert.False(t, log.Enabled(ctx, logger.ErrorLevel))
+			})
+
+			t.Run("should use LevelEnabler", func(t *testing.T) {
+				adapter := &levelEnablerAdapterMock{minLevel: logger.WarnLevel}
+				log := newLogger(adapter)
+				assert.False(t, log.Enabled(ctx, logger.DebugLevel))
+				assert.False(t, log.Enabled(ctx, logger.InfoLevel))
+				assert.True(t, log.Enabled(ctx, logger.WarnLevel))
+				assert.True(t, log.Enabled(ctx, logger.ErrorLevel))
+			})
+
+			t.Run("should not pass entry to adapter when level is disabled", func(t *testing.T) {
+				adapter := &levelEnablerAdapterMock{minLevel: logger.WarnLevel}
+				log := newLogger(adapter)
+				// when
+				log.Info(ctx, message)
+				// then
+				assert.Empty(t, adapter.entries)
+			})
+
+			t.Run("should pass entry to adapter when level is enabled", func(t *testing.T) {
+				adapter := &levelEnablerAdapterMock{minLevel: logger.WarnLevel}
+				log := newLogger(adapter)
+				// when
+				log.Warn(ctx, message)
+				// then
+				adapter.HasExactlyOneEntry(t, logger.Entry{
+					Level:               logger.WarnLevel,
+					Message:             message,
+					Fields:              adapter.entries[0].Fields,
+					SkippedCallerFrames: 2,
+				})
+			})
+		})
+	}
+
+	t.Run("unconfigured global should be enabled only for warnings and errors", func(t *testing.T) {
+		var global logger.Global
+		assert.False(t, global.Enabled(ctx, logger.DebugLevel))
+		assert.False(t, global.Enabled(ctx, logger.InfoLevel))
+		assert.True(t, global.Enabled(ctx, logger.WarnLevel))
+		assert.True(t, global.Enabled(ctx, logger.ErrorLevel))
+	})
+}
+
+func TestEnabledFunction(t *testing.T) {
+	t.Run("should return true when adapter does not implement LevelEnabler", func(t *testing.T) {
+		assert.True(t, logger.Enabled(ctx, &adapterMock{}, logger.DebugLevel))
+	})
+
+	t.Run("should use LevelEnabler", func(t *testing.T) {
+		adapter := &levelEnablerAdapterMock{minLevel: logger.InfoLevel}
+		assert.False(t, logger.Enabled(ctx, adapter, logger.DebugLevel))
+		assert.True(t, logger.Enabled(ctx, adapter, logger.InfoLevel))
+	})
+}
+
+func TestSyncFunction(t *testing.T) {
+	t.Run("should return nil when adapter does not implement Syncer", func(t *testing.T) {
+		assert.NoError(t, logger.Sync(&adapterMock{}))
+	})
+
+	t.Run("should use Syncer", func(t *testing.T) {
+		adapter := &syncCloserAdapterMock{err: ErrSome}
+		// when
+		err := logger.Sync(adapter)
+		// then
+		assert.ErrorIs(t, err, ErrSome)
+		assert.Equal(t, 1, adapter.syncCalled)
+	})
+}
+
+func TestCloseFunction(t *testing.T) {
+	t.Run("should return nil when adapter does not implement Closer", func(t *testing.T) {
+		assert.NoError(t, logger.Close(&adapterMock{}))
+	})
+
+	t.Run("should use Closer", func(t *testing.T) {
+		adapter := &syncCloserAdapterMock{err: ErrSome}
+		// when
+		err := logger.Close(adapter)
+		// then
+		assert.ErrorIs(t, err, ErrSome)
+		assert.Equal(t, 1, adapter.closeCalled)
+	})
+}
+
+func TestGlobal_Sync(t *testing.T) {
+	t.Run("should return nil for not configured global logger", func(t *testing.T) {
+		var global logger.Global
+		assert.NoError(t, global.Sync())
+	})
+
+	t.Run("should sync adapter", func(t *testing.T) {
+		var global logger.Global
+		adapter := &syncCloserAdapterMock{err: ErrSome}
+		global.SetAdapter(adapter)
+		// when
+		err := global.With("k", "v").Sync()
+		// then
+		assert.ErrorIs(t, err, ErrSome)
+		assert.Equal(t, 1, adapter.syncCalled)
+	})
+}
+
+func TestGlobal_Close(t *testing.T) {
+	t.Run("should return nil for not configured global logger", func(t *testing.T) {
+		var global logger.Global
+		assert.NoError(t, global.Close())
+	})
+
+	t.Run("should close adapter", func(t *testing.T) {
+		var global logger.Global
+		adapter := &syncCloserAdapterMock{err: ErrSome}
+		global.SetAdapter(adapter)
+		// when
+		err := global.With("k", "v").Close()
+		// then
+		assert.ErrorIs(t, err, ErrSome)
+		assert.Equal(t, 1, adapter.closeCalled)
+	})
+}
@@ -0,0 +1,150 @@
+// (c) 2022 Jacek Olszak
+// This code is licensed under MIT license (see LICENSE for details)
+
+package logger
+
+import (
+	"path"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// registry contains Global loggers registered using Register or RegisterAs functions.
+var registry = struct {
+	sync.Mutex
+	globals map[string][]*Global
+}{}
+
+// Register registers the global logger under the package path of the caller, for example
+// "github.com/yourorg/yourlib". Thanks to that, the end user can set adapter for all registered loggers at once,
+// without calling SetLoggerAdapter function of each library:
+//
+//	package yourlib
+//	import "github.com/elgopher/yala/logger"
+//
+//	var log logger.Global
+//
+//	func init() {
+//		logger.Register(&log)
+//	}
+//
+// Registration is optional. It is safe to call it concurrently. Registering the same logger twice does nothing.
+func Register(g *Global) {
+	RegisterAs(callerPackagePath(), g)
+}
+
+// RegisterAs registers the global logger under given name. Usually the name is a package path. See Register.
+func RegisterAs(name string, g *Global) {
+	if g == nil {
+		return
+	}
+
+	registry.Lock()
+	defer registry.Unlock()
+
+	if registry.globals == nil {
+		registry.globals = map[string][]*Global{}
+	}
+
+	for _, registered := range registry.globals[name] {
+		if registered == g {
+			return
+		}
+	}
+
+	registry.globals[name] = append(registry.globals[name], g)
+}
+
+func callerPackagePath() string {
+	const skippedFrames = 3 // runtime.Callers, callerPackagePath and Register
+
+	pc := make([]uintptr, 1)
+	if runtime.Callers(skippedFrames, pc) == 0 {
+		return ""
+	}
+
+	frame, _ := runtime.CallersFrames(pc).Next()
+
+	return packagePath(frame.Function)
+}
+
+// packagePath extracts package path from function name, such as "github.com/org/lib.init.0". Go escapes dots in
+// the last element of package path, for example "gopkg.in/yaml%2ev3.init".
+func packagePath(function string) string {
+	lastSlash := strings.LastIndex(function, "/")
+
+	dot := strings.Index(function[lastSlash+1:], ".")
+	if dot >= 0 {
+		function = function[:lastSlash+1+dot]
+	}
+
+	return strings.ReplaceAll(function, "%2e", ".")
+}
+
+// Registered returns sorted names of all registered Global loggers (see Register).
+func Registered() []string {
+	registry.Lock()
+	defer registry.Unlock()
+
+	names := make([]string, 0, len(registry.globals))
+	for name := range registry.globals {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// SetAdapterForAll sets the adapter for all registered Global loggers (see Register), and returns the number
+// of updated loggers. Loggers registered later are not updated.
+func SetAdapterForAll(adapter Adapter) int {
+	return SetAdapterFor("...", adapter)
+}
+
+// SetAdapterFor sets the adapter for registered Global loggers with names matching the pattern, and returns the number
+// of updated loggers. Pattern ending with "/..." matches the package path and all its subpackages, for example
+// "github.com/yourorg/...". "..." alone matches all names. Otherwise, the pattern is matched using path.Match,
+// for example "github.com/*/yourlib". Loggers registered later are not updated.
+func SetAdapterFor(pattern string, adapter Adapter) int {
+	matching := registeredMatching(pattern)
+
+	// adapters are set without holding the lock, because SetAdapter passes buffered entries to the adapter, which
+	// might use the registry too
+	for _, g := range matching {
+		g.SetAdapter(adapter)
+	}
+
+	return len(matching)
+}
+
+func registeredMatching(pattern string) []*Global {
+	registry.Lock()
+	defer registry.Unlock()
+
+	var matching []*Global
+
+	for name, globals := range registry.globals {
+		if matchesPackagePattern(pattern, name) {
+			matching = append(matching, globals...)
+		}
+	}
+
+	return matching
+}
+
+func matchesPackagePattern(pattern, name string) bool {
+	if pattern == "..." {
+		return true
+	}
+
+	if prefix, ok := strings.CutSuffix(pattern, "/..."); ok {
+		return name == prefix || strings.HasPrefix(name, prefix+"/")
+	}
+
+	matched, _ := path.Match(pattern, name)
+
+	return matched
+}
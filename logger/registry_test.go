@@ -0,0 +1,155 @@
+// (c) 2022 Jacek Olszak
+// This code is licensed under MIT license (see LICENSE for details)
+
+package logger_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/elgopher/yala/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegister(t *testing.T) {
+	t.Cleanup(logger.ResetRegistry)
+
+	t.Run("should register logger under package path of the caller", func(t *testing.T) {
+		var global logger.Global
+		// when
+		logger.Register(&global)
+		// then
+		assert.Contains(t, logger.Registered(), "github.com/elgopher/yala/logger_test")
+	})
+}
+
+func TestRegistered(t *testing.T) {
+	t.Cleanup(logger.ResetRegistry)
+
+	t.Run("should return sorted names", func(t *testing.T) {
+		logger.ResetRegistry()
+		logger.RegisterAs("example.com/registered/b", &logger.Global{})
+		logger.RegisterAs("example.com/registered/a", &logger.Global{})
+		logger.RegisterAs("example.com/registered/a", &logger.Global{})
+		// when
+		names := logger.Registered()
+		// then
+		assert.Equal(t, []string{"example.com/registered/a", "example.com/registered/b"}, names)
+	})
+}
+
+func TestSetAdapterFor(t *testing.T) {
+	logger.ResetRegistry()
+	t.Cleanup(logger.ResetRegistry)
+
+	var (
+		lib       logger.Global
+		subpkg    logger.Global
+		other     logger.Global
+		otherSame logger.Global
+	)
+
+	logger.RegisterAs("example.com/org/lib", &lib)
+	logger.RegisterAs("example.com/org/lib/subpkg", &subpkg)
+	logger.RegisterAs("example.com/other/lib", &other)
+	logger.RegisterAs("example.com/other/lib", &otherSame)
+	logger.RegisterAs("example.com/other/lib", &otherSame) // registering twice does nothing
+
+	t.Run("should set adapter for loggers matching the pattern", func(t *testing.T) {
+		tests := map[string]struct {
+			pattern         string
+			expectedUpdated int
+			expectedLoggers []*logger.Global
+			expectedSkipped []*logger.Global
+		}{
+			"package path": {
+				pattern:         "example.com/org/lib",
+				expectedLoggers: []*logger.Global{&lib},
+				expectedSkipped: []*logger.Global{&subpkg, &other, &otherSame},
+			},
+			"package with subpackages": {
+				pattern:         "example.com/org/...",
+				expectedLoggers: []*logger.Global{&lib, &subpkg},
+				expectedSkipped: []*logger.Global{&other, &otherSame},
+			},
+			"glob": {
+				pattern:         "example.com/*/lib",
+				expectedLoggers: []*logger.Global{&lib, &other, &otherSame},
+				expectedSkipped: []*logger.Global{&subpkg},
+			},
+		}
+
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				logger.SetAdapterFor("example.com/...", nil)
+				adapter := &adapterMock{}
+				// when
+				updated := logger.SetAdapterFor(test.pattern, adapter)
+				// then
+				assert.Equal(t, len(test.expectedLoggers), updated)
+
+				for _, global := range test.expectedLoggers {
+					global.Info(ctx, message)
+				}
+
+				for _, global := range test.expectedSkipped {
+					global.Info(ctx, message)
+				}
+
+				assert.Len(t, adapter.entries, len(test.expectedLoggers))
+			})
+		}
+	})
+
+	t.Run("SetAdapterForAll should set adapter for all loggers", func(t *testing.T) {
+		adapter := &adapterMock{}
+		// when
+		updated := logger.SetAdapterForAll(adapter)
+		// then
+		assert.Equal(t, 4, updated)
+		lib.Info(ctx, message)
+		subpkg.Info(ctx, message)
+		other.Info(ctx, message)
+		otherSame.Info(ctx, message)
+		require.Len(t, adapter.entries, 4)
+	})
+
+	t.Run("should update child loggers", func(t *testing.T) {
+		child := lib.With("k", "v")
+		adapter := &adapterMock{}
+		// when
+		logger.SetAdapterFor("example.com/org/lib", adapter)
+		// then
+		child.Info(ctx, message)
+		assert.Len(t, adapter.entries, 1)
+	})
+
+	t.Run("should not deadlock when adapter uses registry while buffered entries are passed", func(t *testing.T) {
+		logger.BufferEarlyEntries(logger.EarlyEntries{Size: 10})
+		t.Cleanup(func() {
+			logger.BufferEarlyEntries(logger.EarlyEntries{})
+		})
+
+		var global logger.Global
+		logger.RegisterAs("example.com/buffered", &global)
+		global.Info(ctx, message)
+
+		var namesSeenByAdapter []string
+
+		adapter := logFunc(func(context.Context, logger.Entry) {
+			namesSeenByAdapter = logger.Registered()
+		})
+		// when
+		updated := logger.SetAdapterFor("example.com/buffered", adapter)
+		// then
+		assert.Equal(t, 1, updated)
+		assert.Contains(t, namesSeenByAdapter, "example.com/buffered")
+	})
+}
+
+type logFunc func(context.Context, logger.Entry)
+
+func (f logFunc) Log(ctx context.Context, entry logger.Entry) {
+	f(ctx, entry)
+}
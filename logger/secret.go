@@ -0,0 +1,56 @@
+// (c) 2022 Jacek Olszak
+// This code is licensed under MIT license (see LICENSE for details)
+
+package logger
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+// Redacted is logged instead of sensitive values. See Secret.
+const Redacted = "[REDACTED]"
+
+// Secret wraps a sensitive value, such as password or token, which should never be logged. Secret is formatted as
+// Redacted by fmt package, encoding/json, log/slog and all adapters:
+//
+//	log.InfoKV(ctx, "User logged in", "password", logger.NewSecret(password)) // password=[REDACTED]
+type Secret struct {
+	value interface{}
+}
+
+// NewSecret wraps the sensitive value.
+func NewSecret(value interface{}) Secret {
+	return Secret{value: value}
+}
+
+// Value returns the wrapped value.
+func (s Secret) Value() interface{} {
+	return s.value
+}
+
+// String returns Redacted.
+func (s Secret) String() string {
+	return Redacted
+}
+
+// GoString returns Redacted. It is used by fmt package for %#v verb.
+func (s Secret) GoString() string {
+	return Redacted
+}
+
+// Format writes Redacted, no matter what verb was used.
+func (s Secret) Format(state fmt.State, _ rune) {
+	_, _ = state.Write([]byte(Redacted))
+}
+
+// MarshalText returns Redacted. It implements encoding.TextMarshaler, therefore Secret is marshalled to JSON string
+// too.
+func (s Secret) MarshalText() ([]byte, error) {
+	return []byte(Redacted), nil
+}
+
+// LogValue returns Redacted. It implements slog.LogValuer.
+func (s Secret) LogValue() slog.Value {
+	return slog.StringValue(Redacted)
+}
@@ -0,0 +1,47 @@
+// (c) 2022 Jacek Olszak
+// This code is licensed under MIT license (see LICENSE for details)
+
+package logger_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/elgopher/yala/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecret(t *testing.T) {
+	secret := logger.NewSecret("password")
+
+	t.Run("should return wrapped value", func(t *testing.T) {
+		assert.Equal(t, "password", secret.Value())
+	})
+
+	t.Run("should format as redacted", func(t *testing.T) {
+		for _, format := range []string{"%v", "%+v", "%#v", "%s", "%q", "%d"} {
+			t.Run(format, func(t *testing.T) {
+				assert.Equal(t, logger.Redacted, fmt.Sprintf(format, secret))
+			})
+		}
+	})
+
+	t.Run("should marshal to JSON as redacted", func(t *testing.T) {
+		marshalled, err := json.Marshal(map[string]interface{}{"password": secret})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"password":"[REDACTED]"}`, string(marshalled))
+	})
+
+	t.Run("should log as redacted using log/slog", func(t *testing.T) {
+		var buffer bytes.Buffer
+		log := slog.New(slog.NewTextHandler(&buffer, nil))
+		// when
+		log.Info(message, "password", secret)
+		// then
+		assert.Contains(t, buffer.String(), "password=[REDACTED]")
+	})
+}
@@ -0,0 +1,49 @@
+// (c) 2022 Jacek Olszak
+// This code is licensed under MIT license (see LICENSE for details)
+
+package logger
+
+import (
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// Stack is a stack trace - program counters of functions which led to logging the entry. The first one is the
+// caller.
+type Stack []uintptr
+
+// String formats the stack trace. Each frame is printed in two lines - function name and then file with line number,
+// indented with a tab:
+//
+//	main.function
+//		/path/to/file.go:12
+//	main.main
+//		/path/to/main.go:5
+func (s Stack) String() string {
+	if len(s) == 0 {
+		return ""
+	}
+
+	var builder strings.Builder
+
+	frames := runtime.CallersFrames(s)
+
+	for {
+		frame, more := frames.Next()
+
+		builder.WriteString(frame.Function)
+		builder.WriteString("\n\t")
+		builder.WriteString(frame.File)
+		builder.WriteByte(':')
+		builder.WriteString(strconv.Itoa(frame.Line))
+
+		if !more {
+			break
+		}
+
+		builder.WriteByte('\n')
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,37 @@
+// (c) 2022 Jacek Olszak
+// This code is licensed under MIT license (see LICENSE for details)
+
+package logger_test
+
+import (
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/elgopher/yala/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStack_String(t *testing.T) {
+	t.Run("should return empty string for nil stack", func(t *testing.T) {
+		var stack logger.Stack
+		assert.Equal(t, "", stack.String())
+	})
+
+	t.Run("should format frames", func(t *testing.T) {
+		pcs := make([]uintptr, 2)
+		runtime.Callers(1, pcs)
+		_, file, line, _ := runtime.Caller(0)
+		stack := logger.Stack(pcs)
+		// when
+		actual := stack.String()
+		// then
+		lines := strings.Split(actual, "\n")
+		assert.Len(t, lines, 4)
+		assert.True(t, strings.HasPrefix(lines[0], "github.com/elgopher/yala/logger_test.TestStack_String."))
+		assert.Equal(t, "\t"+file+":"+strconv.Itoa(line-1), lines[1])
+		assert.Equal(t, "testing.tRunner", lines[2])
+		assert.True(t, strings.HasPrefix(lines[3], "\t"))
+	})
+}
@@ -0,0 +1,65 @@
+// (c) 2022 Jacek Olszak
+// This code is licensed under MIT license (see LICENSE for details)
+
+package logger
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// stderrAdapter is a minimal adapter printing entries to stderr. It is used when no adapter was configured by the end
+// user. The format is:
+//
+//	time LEVEL message logger=name key=value error=error
+type stderrAdapter struct {
+	writer io.Writer // nil means os.Stderr
+}
+
+func (s stderrAdapter) Log(_ context.Context, entry Entry) {
+	entryTime := entry.Time
+	if entryTime.IsZero() {
+		entryTime = time.Now()
+	}
+
+	var builder strings.Builder
+
+	builder.WriteString(entryTime.Format(time.RFC3339Nano))
+	builder.WriteByte(' ')
+	builder.WriteString(entry.Level.String())
+	builder.WriteByte(' ')
+	builder.WriteString(entry.Message)
+
+	if entry.Name != "" {
+		_, _ = fmt.Fprintf(&builder, " %s=%s", NameKey, entry.Name)
+	}
+
+	groups := ""
+
+	for _, field := range entry.Fields {
+		if field.Kind() == KindGroup {
+			groups += field.Key + "."
+
+			continue
+		}
+
+		_, _ = fmt.Fprintf(&builder, " %s%s=%v", groups, field.Key, field.AnyValue())
+	}
+
+	if entry.Error != nil {
+		_, _ = fmt.Fprintf(&builder, " error=%s", entry.Error)
+	}
+
+	builder.WriteByte('\n')
+
+	writer := s.writer
+	if writer == nil {
+		writer = os.Stderr
+	}
+
+	_, _ = io.WriteString(writer, builder.String())
+}
@@ -0,0 +1,143 @@
+// (c) 2022 Jacek Olszak
+// This code is licensed under MIT license (see LICENSE for details)
+
+package logger
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// UnconfiguredPolicy defines what Global logger does with entries logged before SetAdapter was called.
+// See SetUnconfiguredPolicy.
+type UnconfiguredPolicy uint8
+
+const (
+	// UnconfiguredWarnOnce prints a warning to stderr, when the first entry with WarnLevel or ErrorLevel is logged.
+	// All entries are discarded. This is the default policy.
+	UnconfiguredWarnOnce UnconfiguredPolicy = iota
+	// UnconfiguredSilent discards all entries.
+	UnconfiguredSilent
+	// UnconfiguredStderr prints all entries to stderr.
+	UnconfiguredStderr
+	// UnconfiguredPanic panics when any entry is logged. It can be used in tests to find loggers which were not
+	// configured.
+	UnconfiguredPanic
+)
+
+var unconfiguredPolicyNames = []string{"warn", "silent", "stderr", "panic"}
+
+// String converts the UnconfiguredPolicy to a string accepted by ParseUnconfiguredPolicy, for example "warn".
+func (p UnconfiguredPolicy) String() string {
+	if int(p) < len(unconfiguredPolicyNames) {
+		return unconfiguredPolicyNames[p]
+	}
+
+	return fmt.Sprintf("UnconfiguredPolicy(%d)", p)
+}
+
+// ParseUnconfiguredPolicy converts a string to UnconfiguredPolicy. It accepts (case-insensitive) "warn", "silent",
+// "stderr" and "panic".
+func ParseUnconfiguredPolicy(s string) (UnconfiguredPolicy, error) {
+	for i, name := range unconfiguredPolicyNames {
+		if strings.EqualFold(s, name) {
+			return UnconfiguredPolicy(i), nil
+		}
+	}
+
+	return 0, fmt.Errorf("invalid unconfigured policy %q", s)
+}
+
+// UnconfiguredPolicyEnv is the name of environment variable with the policy used when SetUnconfiguredPolicy was not
+// called, for example "silent". See ParseUnconfiguredPolicy.
+const UnconfiguredPolicyEnv = "YALA_UNCONFIGURED"
+
+var unconfiguredPolicy struct {
+	sync.Mutex
+	policy     UnconfiguredPolicy
+	configured bool
+}
+
+// SetUnconfiguredPolicy sets what Global loggers do with entries logged before SetAdapter was called. By default,
+// the policy is taken from UnconfiguredPolicyEnv environment variable, or UnconfiguredWarnOnce is used when
+// the variable is not set.
+//
+// The policy is used by all Global loggers which are not configured yet, unless buffering of early entries
+// is enabled (see BufferEarlyEntries).
+func SetUnconfiguredPolicy(policy UnconfiguredPolicy) {
+	unconfiguredPolicy.Lock()
+	defer unconfiguredPolicy.Unlock()
+
+	unconfiguredPolicy.policy = policy
+	unconfiguredPolicy.configured = true
+}
+
+func currentUnconfiguredPolicy() UnconfiguredPolicy {
+	unconfiguredPolicy.Lock()
+	defer unconfiguredPolicy.Unlock()
+
+	if !unconfiguredPolicy.configured {
+		if policy, err := ParseUnconfiguredPolicy(os.Getenv(UnconfiguredPolicyEnv)); err == nil {
+			unconfiguredPolicy.policy = policy
+		}
+
+		unconfiguredPolicy.configured = true
+	}
+
+	return unconfiguredPolicy.policy
+}
+
+// unconfiguredAdapter is an initial adapter of Global, used until SetAdapter is called. The policy is checked
+// on each call, so it can be changed after the Global was used.
+type unconfiguredAdapter struct {
+	warnOnce sync.Once
+}
+
+func (u *unconfiguredAdapter) Enabled(_ context.Context, level Level) bool {
+	switch currentUnconfiguredPolicy() {
+	case UnconfiguredSilent:
+		return false
+	case UnconfiguredWarnOnce:
+		return level == WarnLevel || level == ErrorLevel
+	default:
+		return true
+	}
+}
+
+// ReportsCaller returns true for policies printing the caller location.
+func (u *unconfiguredAdapter) ReportsCaller() bool {
+	policy := currentUnconfiguredPolicy()
+
+	return policy == UnconfiguredWarnOnce || policy == UnconfiguredPanic
+}
+
+func (u *unconfiguredAdapter) Log(ctx context.Context, entry Entry) {
+	switch currentUnconfiguredPolicy() {
+	case UnconfiguredSilent:
+	case UnconfiguredWarnOnce:
+		if entry.Level == WarnLevel || entry.Level == ErrorLevel {
+			u.warnOnce.Do(func() {
+				_, _ = fmt.Fprintf(os.Stderr, "%s cannot log message with level %s. Please configure the global logger.\n",
+					callerLocation(entry), entry.Level)
+			})
+		}
+	case UnconfiguredStderr:
+		entry.SkippedCallerFrames++
+		stderrAdapter{}.Log(ctx, entry)
+	case UnconfiguredPanic:
+		panic(fmt.Sprintf("%s cannot log message %q with level %s, because the global logger is not configured",
+			callerLocation(entry), entry.Message, entry.Level))
+	}
+}
+
+func callerLocation(entry Entry) string {
+	caller, ok := entry.Caller()
+	if !ok {
+		return "unknown"
+	}
+
+	return fmt.Sprintf("%s:%d", caller.File, caller.Line)
+}
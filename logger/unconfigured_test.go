@@ -0,0 +1,147 @@
+// (c) 2022 Jacek Olszak
+// This code is licensed under MIT license (see LICENSE for details)
+
+package logger_test
+
+import (
+	"io"
+	"os"
+	"testing"
+
+	"github.com/elgopher/yala/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseUnconfiguredPolicy(t *testing.T) {
+	t.Run("should parse policy", func(t *testing.T) {
+		tests := map[string]logger.UnconfiguredPolicy{
+			"warn":   logger.UnconfiguredWarnOnce,
+			"silent": logger.UnconfiguredSilent,
+			"STDERR": logger.UnconfiguredStderr,
+			"panic":  logger.UnconfiguredPanic,
+		}
+
+		for s, expected := range tests {
+			t.Run(s, func(t *testing.T) {
+				policy, err := logger.ParseUnconfiguredPolicy(s)
+				require.NoError(t, err)
+				assert.Equal(t, expected, policy)
+			})
+		}
+	})
+
+	t.Run("should return error for invalid policy", func(t *testing.T) {
+		_, err := logger.ParseUnconfiguredPolicy("invalid")
+		assert.Error(t, err)
+	})
+
+	t.Run("should convert policy to string", func(t *testing.T) {
+		assert.Equal(t, "stderr", logger.UnconfiguredStderr.String())
+	})
+}
+
+func TestSetUnconfiguredPolicy(t *testing.T) {
+	setUnconfiguredPolicy := func(t *testing.T, policy logger.UnconfiguredPolicy) {
+		t.Helper()
+		logger.SetUnconfiguredPolicy(policy)
+		t.Cleanup(func() {
+			logger.SetUnconfiguredPolicy(logger.UnconfiguredWarnOnce)
+		})
+	}
+
+	t.Run("should warn once to stderr", func(t *testing.T) {
+		setUnconfiguredPolicy(t, logger.UnconfiguredWarnOnce)
+
+		var global logger.Global
+		// when
+		output := captureStderr(t, func() {
+			global.Info(ctx, message)
+			global.Warn(ctx, message)
+			global.Error(ctx, message)
+		})
+		// then
+		assert.Regexp(t, `^.*unconfigured_test.go:\d+ cannot log message with level WARN. Please configure the global logger.\n$`, output)
+	})
+
+	t.Run("should not print anything when policy is silent", func(t *testing.T) {
+		setUnconfiguredPolicy(t, logger.UnconfiguredSilent)
+
+		var global logger.Global
+		// when
+		output := captureStderr(t, func() {
+			global.Error(ctx, message)
+		})
+		// then
+		assert.Empty(t, output)
+		assert.False(t, global.Enabled(ctx, logger.ErrorLevel))
+	})
+
+	t.Run("should print all entries to stderr", func(t *testing.T) {
+		setUnconfiguredPolicy(t, logger.UnconfiguredStderr)
+
+		var global logger.Global
+		// when
+		output := captureStderr(t, func() {
+			global.Debug(ctx, "debug")
+			global.Named("lib").WithError(ErrSome).ErrorKV(ctx, "error", "k", "v")
+		})
+		// then
+		assert.Regexp(t, `^\S+ DEBUG debug\n\S+ ERROR error logger=lib k=v error=some error\n$`, output)
+	})
+
+	t.Run("should panic", func(t *testing.T) {
+		setUnconfiguredPolicy(t, logger.UnconfiguredPanic)
+
+		var global logger.Global
+		assert.Panics(t, func() {
+			global.Debug(ctx, message)
+		})
+	})
+
+	t.Run("should use policy changed after global logger was used", func(t *testing.T) {
+		setUnconfiguredPolicy(t, logger.UnconfiguredSilent)
+
+		var global logger.Global
+		global.Info(ctx, message)
+		// when
+		logger.SetUnconfiguredPolicy(logger.UnconfiguredPanic)
+		// then
+		assert.Panics(t, func() {
+			global.Info(ctx, message)
+		})
+	})
+
+	t.Run("should not use policy when adapter is set", func(t *testing.T) {
+		setUnconfiguredPolicy(t, logger.UnconfiguredPanic)
+
+		var global logger.Global
+		global.SetAdapter(&adapterMock{})
+		assert.NotPanics(t, func() {
+			global.Info(ctx, message)
+		})
+	})
+}
+
+func captureStderr(t *testing.T, f func()) string {
+	t.Helper()
+
+	reader, writer, err := os.Pipe()
+	require.NoError(t, err)
+
+	stderr := os.Stderr
+	os.Stderr = writer
+
+	defer func() {
+		os.Stderr = stderr
+	}()
+
+	f()
+
+	require.NoError(t, writer.Close())
+
+	output, err := io.ReadAll(reader)
+	require.NoError(t, err)
+
+	return string(output)
+}